@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/ulikunitz/xz"
+)
+
+// maxMagicLength is the length of the longest magic number of compressionFormats
+const maxMagicLength = 6
+
+// compressionFormat is detected by the magic number at the beginning of the input
+type compressionFormat struct {
+	name      string
+	magic     []byte
+	newReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+// compressionFormats are all supported compression formats
+var compressionFormats = []*compressionFormat{
+	{
+		name:  "gzip",
+		magic: []byte{0x1f, 0x8b},
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	},
+	{
+		name:  "zstd",
+		magic: []byte{0x28, 0xb5, 0x2f, 0xfd},
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			d, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+
+			return d.IOReadCloser(), nil
+		},
+	},
+	{
+		name:  "bzip2",
+		magic: []byte("BZh"),
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(bzip2.NewReader(r)), nil
+		},
+	},
+	{
+		name:  "xz",
+		magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00},
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			x, err := xz.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+
+			return io.NopCloser(x), nil
+		},
+	},
+}
+
+// decompressedReader closes the decompressor and the underlying input
+type decompressedReader struct {
+	io.ReadCloser
+	input io.Closer
+}
+
+func (d *decompressedReader) Close() error {
+	err := d.ReadCloser.Close()
+	if inputErr := d.input.Close(); err == nil {
+		err = inputErr
+	}
+
+	return err
+}
+
+// decompressFile returns a regular file itself if it is not compressed, so it could still be seeked.
+// Files which could not be seeked like pipes or /dev/stdin are read as stream.
+func decompressFile(f *os.File) (io.ReadCloser, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.Mode().IsRegular() {
+		return decompressStream(f)
+	}
+
+	header := make([]byte, maxMagicLength)
+
+	n, err := f.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return decompress(f, f, header[:n])
+}
+
+// decompressStream sniffs the magic number of a stream which could not be seeked, e.g. stdin
+func decompressStream(r io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	header, err := br.Peek(maxMagicLength)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return decompress(br, r, header)
+}
+
+// decompress wraps r with a decompressor if the header starts with a known magic number
+func decompress(r io.Reader, input io.ReadCloser, header []byte) (io.ReadCloser, error) {
+	if format := detectCompression(header); format != nil {
+		d, err := format.newReader(r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s input", format.name)
+		}
+
+		return &decompressedReader{ReadCloser: d, input: input}, nil
+	}
+
+	if rc, ok := r.(io.ReadCloser); ok {
+		return rc, nil
+	}
+
+	return &decompressedReader{ReadCloser: io.NopCloser(r), input: input}, nil
+}
+
+// detectCompression returns the compression format whose magic number starts the header,
+// nil if the input is not compressed
+func detectCompression(header []byte) *compressionFormat {
+	for _, format := range compressionFormats {
+		if bytes.HasPrefix(header, format.magic) {
+			return format
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/ulikunitz/xz"
+)
+
+const decompressLine = `{"level":"INFO","message":"bz"}` + "\n"
+
+// bzip2Line is decompressLine compressed with bzip2, the standard library has no bzip2 writer
+var bzip2Line = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x71, 0x6d,
+	0x92, 0x31, 0x00, 0x00, 0x0f, 0x5d, 0x80, 0x00, 0x10, 0x10, 0x04, 0x00,
+	0x10, 0x01, 0x21, 0xb2, 0x86, 0x09, 0x1a, 0x20, 0x00, 0x31, 0x4c, 0x98,
+	0x99, 0x06, 0x46, 0x11, 0x00, 0xc8, 0x7a, 0x43, 0x6a, 0x16, 0x70, 0xa8,
+	0x58, 0xe6, 0x42, 0x60, 0x45, 0x7c, 0x4b, 0xc7, 0x30, 0x8b, 0x44, 0x26,
+	0xdf, 0x17, 0x72, 0x45, 0x38, 0x50, 0x90, 0x71, 0x6d, 0x92, 0x31,
+}
+
+func compressed(t *testing.T, newWriter func(w io.Writer) (io.WriteCloser, error)) []byte {
+	var buf bytes.Buffer
+
+	w, err := newWriter(&buf)
+	assert.NoError(t, err)
+	_, err = w.Write([]byte(decompressLine))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func Test_openInput_decompress(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "plain",
+			content: []byte(decompressLine),
+			want:    decompressLine,
+		},
+		{
+			name: "gzip",
+			content: compressed(t, func(w io.Writer) (io.WriteCloser, error) {
+				return gzip.NewWriter(w), nil
+			}),
+			want: decompressLine,
+		},
+		{
+			name: "zstd",
+			content: compressed(t, func(w io.Writer) (io.WriteCloser, error) {
+				return zstd.NewWriter(w)
+			}),
+			want: decompressLine,
+		},
+		{
+			name: "xz",
+			content: compressed(t, func(w io.Writer) (io.WriteCloser, error) {
+				return xz.NewWriter(w)
+			}),
+			want: decompressLine,
+		},
+		{
+			name:    "bzip2",
+			content: bzip2Line,
+			want:    decompressLine,
+		},
+		{
+			name:    "empty",
+			content: []byte{},
+			want:    "",
+		},
+		{
+			name:    "corrupt gzip",
+			content: []byte{0x1f, 0x8b, 0x00},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "app.log")
+			assert.NoError(t, os.WriteFile(name, tt.content, 0o600))
+
+			r, err := openInput(name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("openInput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			defer r.Close()
+
+			got, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_decompressStream(t *testing.T) {
+	gz := compressed(t, func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	})
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{name: "plain", content: []byte(decompressLine), want: decompressLine},
+		{name: "gzip", content: gz, want: decompressLine},
+		{name: "short", content: []byte("1"), want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := decompressStream(io.NopCloser(bytes.NewReader(tt.content)))
+			assert.NoError(t, err)
+			defer r.Close()
+
+			got, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_decompressFile_pipe(t *testing.T) {
+	gz := compressed(t, func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	})
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{name: "plain", content: []byte(decompressLine), want: decompressLine},
+		{name: "gzip", content: gz, want: decompressLine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr, pw, err := os.Pipe()
+			assert.NoError(t, err)
+
+			go func() {
+				_, _ = pw.Write(tt.content)
+				pw.Close()
+			}()
+
+			r, err := decompressFile(pr)
+			assert.NoError(t, err)
+			defer r.Close()
+
+			got, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
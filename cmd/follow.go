@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// followInterval is the time to wait for new data after the end of a followed file was reached
+var followInterval = 250 * time.Millisecond
+
+// tailChunkSize is the size of the chunks read backwards to find the last lines of a file
+const tailChunkSize = 4096
+
+// follower reads a file like tail -F: at the end of the file it waits for appended data,
+// reopens the file if it was renamed or recreated and starts over if it was truncated
+type follower struct {
+	name     string
+	file     *os.File
+	interval time.Duration
+	done     <-chan struct{}
+}
+
+// newFollower opens the file, skips all but the last tailLines lines if tailLines >= 0
+// and follows the file until done is closed
+func newFollower(name string, tailLines int, done <-chan struct{}) (*follower, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rejectCompressed(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, name)
+	}
+
+	if tailLines >= 0 {
+		err = seekLastLines(f, tailLines)
+		if err != nil {
+			f.Close()
+			return nil, errors.Wrap(err, name)
+		}
+	}
+
+	return &follower{name: name, file: f, interval: followInterval, done: done}, nil
+}
+
+// rejectCompressed returns an error for compressed files, appended data could not be decompressed
+func rejectCompressed(f *os.File) error {
+	header := make([]byte, maxMagicLength)
+
+	n, err := f.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	if format := detectCompression(header[:n]); format != nil {
+		return errors.Errorf("%s compressed files could not be followed", format.name)
+	}
+
+	return nil
+}
+
+// Read blocks until data is available, io.EOF is only returned after done was closed
+func (f *follower) Read(p []byte) (int, error) {
+	for {
+		n, err := f.file.Read(p)
+		if n > 0 {
+			return n, nil
+		}
+
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		rotated, err := f.reopenIfRotated()
+		if err != nil {
+			return 0, err
+		}
+
+		if rotated {
+			continue
+		}
+
+		select {
+		case <-f.done:
+			return 0, io.EOF
+		case <-time.After(f.interval):
+		}
+	}
+}
+
+// reopenIfRotated switches to the new file if the followed file was renamed or recreated
+// and rewinds the file if it was truncated
+func (f *follower) reopenIfRotated() (bool, error) {
+	info, err := os.Stat(f.name)
+	if err != nil {
+		// the file is missing between rename and recreation, wait for it
+		return false, nil //nolint:nilerr // not an error while following
+	}
+
+	current, err := f.file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	offset, err := f.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+
+	if !os.SameFile(info, current) {
+		// read the rest of the rotated file before switching to the new one
+		if current.Size() > offset {
+			return true, nil
+		}
+
+		next, err := os.Open(f.name)
+		if err != nil {
+			return false, nil //nolint:nilerr // retried on the next read
+		}
+
+		f.file.Close()
+		f.file = next
+
+		return true, nil
+	}
+
+	if info.Size() < offset {
+		_, err = f.file.Seek(0, io.SeekStart)
+		return err == nil, err
+	}
+
+	return false, nil
+}
+
+// Close closes the currently followed file
+func (f *follower) Close() error {
+	return f.file.Close()
+}
+
+// seekLastLines positions the file at the beginning of its last n lines
+func seekLastLines(f *os.File, n int) error {
+	end, err := f.Seek(0, io.SeekEnd)
+	if err != nil || n <= 0 {
+		return err
+	}
+
+	offset := end
+	buf := make([]byte, tailChunkSize)
+	found := 0
+
+	for offset > 0 {
+		size := int64(len(buf))
+		if offset < size {
+			size = offset
+		}
+
+		offset -= size
+
+		_, err = f.ReadAt(buf[:size], offset)
+		if err != nil {
+			return err
+		}
+
+		for i := size - 1; i >= 0; i-- {
+			// a newline at the very end of the file terminates the last line
+			if buf[i] != '\n' || offset+i == end-1 {
+				continue
+			}
+
+			found++
+			if found == n {
+				_, err = f.Seek(offset+i+1, io.SeekStart)
+				return err
+			}
+		}
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+
+	return err
+}
+
+// lockedWriter serializes writes of concurrently followed files
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	return lw.w.Write(p)
+}
+
+// followFiles converts all files concurrently while following them until done is closed,
+// files which could not be read are reported to errW immediately. Every file gets its own
+// convert function of newConvert, as converters keep state like the previous time.
+func followFiles(newConvert func() (func(r io.Reader, w io.Writer) error, error), names []string, tailLines int, w, errW io.Writer, done <-chan struct{}) error {
+	for _, name := range names {
+		if name == stdinName {
+			return errors.New("stdin could not be followed, use file arguments with --follow")
+		}
+	}
+
+	out := &lockedWriter{w: w}
+	errOut := &lockedWriter{w: errW}
+
+	var wg sync.WaitGroup
+
+	var failed int32
+
+	for _, name := range names {
+		wg.Add(1)
+
+		go func(name string) {
+			defer wg.Done()
+
+			convert, err := newConvert()
+			if err == nil {
+				err = followFile(convert, name, tailLines, out, done)
+			}
+
+			if err != nil {
+				fmt.Fprintf(errOut, "Error: %v\n", err)
+				atomic.AddInt32(&failed, 1)
+			}
+		}(name)
+	}
+
+	wg.Wait()
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d files could not be followed", failed, len(names))
+	}
+
+	return nil
+}
+
+func followFile(convert func(r io.Reader, w io.Writer) error, name string, tailLines int, w io.Writer, done <-chan struct{}) error {
+	f, err := newFollower(name, tailLines, done)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = convert(f, w)
+	if err != nil {
+		return errors.Wrap(err, name)
+	}
+
+	return nil
+}
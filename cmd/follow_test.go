@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_seekLastLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		n       int
+		want    string
+	}{
+		{name: "last two lines", content: "a\nb\nc\n", n: 2, want: "b\nc\n"},
+		{name: "without trailing newline", content: "a\nb\nc", n: 1, want: "c"},
+		{name: "more lines than available", content: "a\nb\n", n: 5, want: "a\nb\n"},
+		{name: "zero lines", content: "a\nb\n", n: 0, want: ""},
+		{name: "empty file", content: "", n: 3, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "app.log")
+			assert.NoError(t, os.WriteFile(name, []byte(tt.content), 0o600))
+
+			f, err := os.Open(name)
+			assert.NoError(t, err)
+			defer f.Close()
+
+			assert.NoError(t, seekLastLines(f, tt.n))
+			got, err := io.ReadAll(f)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_follower(t *testing.T) {
+	followInterval = 5 * time.Millisecond
+	defer func() { followInterval = 250 * time.Millisecond }()
+
+	name := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(name, []byte("old 1\nold 2\n"), 0o600))
+
+	done := make(chan struct{})
+	f, err := newFollower(name, 1, done)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	next := func() string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for line")
+			return ""
+		}
+	}
+
+	appendLine := func(line string) {
+		file, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0o600)
+		assert.NoError(t, err)
+		_, err = file.WriteString(line + "\n")
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+	}
+
+	assert.Equal(t, "old 2", next())
+
+	appendLine("appended")
+	assert.Equal(t, "appended", next())
+
+	// logrotate: rename and recreate
+	assert.NoError(t, os.Rename(name, name+".1"))
+	assert.NoError(t, os.WriteFile(name, []byte("rotated\n"), 0o600))
+	assert.Equal(t, "rotated", next())
+
+	// copytruncate
+	assert.NoError(t, os.Truncate(name, 0))
+	time.Sleep(20 * time.Millisecond)
+	appendLine("truncated")
+	assert.Equal(t, "truncated", next())
+
+	close(done)
+	_, ok := <-lines
+	assert.False(t, ok)
+}
+
+func Test_followFiles(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(name, []byte("first\nsecond\n"), 0o600))
+
+	compressed := filepath.Join(t.TempDir(), "app.log.gz")
+	assert.NoError(t, os.WriteFile(compressed, []byte{0x1f, 0x8b, 0x08, 0x00}, 0o600))
+
+	done := make(chan struct{})
+	close(done)
+
+	tests := []struct {
+		name     string
+		names    []string
+		wantW    string
+		wantErrW string
+		wantErr  bool
+	}{
+		{
+			name:  "stops when done",
+			names: []string{name},
+			wantW: "1 second\n",
+		},
+		{
+			name:  "converter per file",
+			names: []string{name, name},
+			wantW: "1 second\n1 second\n",
+		},
+		{
+			name:     "missing file",
+			names:    []string{filepath.Join(t.TempDir(), "missing.log")},
+			wantErrW: "Error: open ",
+			wantErr:  true,
+		},
+		{
+			name:     "compressed file",
+			names:    []string{compressed},
+			wantErrW: "gzip compressed files could not be followed",
+			wantErr:  true,
+		},
+		{
+			name:    "stdin",
+			names:   []string{"-"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			errW := &bytes.Buffer{}
+			// numbers the lines of each converter to show whether converters are shared
+			newConvert := func() (func(r io.Reader, w io.Writer) error, error) {
+				count := 0
+				return func(r io.Reader, w io.Writer) error {
+					scanner := bufio.NewScanner(r)
+					for scanner.Scan() {
+						count++
+						fmt.Fprintf(w, "%d %s\n", count, scanner.Text())
+					}
+					return scanner.Err()
+				}, nil
+			}
+			err := followFiles(newConvert, tt.names, 1, w, errW, done)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("followFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantW, w.String())
+			assert.Contains(t, errW.String(), tt.wantErrW)
+		})
+	}
+}
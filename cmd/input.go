@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fhopfensperger/json-log-to-human-readable/pkg/humanlog"
+	"github.com/pkg/errors"
+)
+
+// stdinName is the file argument for reading from stdin
+const stdinName = "-"
+
+// expandArgs expands glob patterns of the file arguments in order,
+// arguments which are no pattern are returned unchanged
+func expandArgs(args []string) ([]string, error) {
+	var names []string
+
+	for _, arg := range args {
+		if arg == stdinName || !strings.ContainsAny(arg, "*?[") {
+			names = append(names, arg)
+			continue
+		}
+
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", arg)
+		}
+
+		if len(matches) == 0 {
+			return nil, errors.Errorf("%s: no files match the pattern", arg)
+		}
+
+		names = append(names, matches...)
+	}
+
+	return names, nil
+}
+
+// openInput opens the file with the given name or stdin for "-",
+// compressed input is decompressed on the fly
+func openInput(name string) (io.ReadCloser, error) {
+	if name == stdinName {
+		return decompressStream(io.NopCloser(os.Stdin))
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decompressFile(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, name)
+	}
+
+	return r, nil
+}
+
+// filesToHumanReadable converts the files one after another to w, files which could
+// not be read are reported to errW and the remaining files are processed anyway
+func filesToHumanReadable(args []string, w, errW io.Writer) error {
+	names, err := expandArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if followInput {
+		if mergeInput {
+			return errors.New("--merge could not be combined with --follow")
+		}
+
+		newConvert := func() (func(r io.Reader, w io.Writer) error, error) {
+			converter, err := newConverter(w)
+			if err != nil {
+				return nil, err
+			}
+
+			return converter.Convert, nil
+		}
+
+		return followFiles(newConvert, names, tailLines, w, errW, nil)
+	}
+
+	converter, err := newConverter(w)
+	if err != nil {
+		return err
+	}
+
+	if mergeInput {
+		return mergeFiles(converter, names, w, errW)
+	}
+
+	failed := 0
+
+	for _, name := range names {
+		err := convertFile(converter.Convert, name, w)
+		if err != nil {
+			fmt.Fprintf(errW, "Error: %v\n", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d files could not be processed", failed, len(names))
+	}
+
+	return nil
+}
+
+func convertFile(convert func(r io.Reader, w io.Writer) error, name string, w io.Writer) error {
+	r, err := openTail(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	err = convert(r, w)
+	if err != nil {
+		return errors.Wrap(err, name)
+	}
+
+	return nil
+}
+
+// openTail opens the input like openInput and skips all but the last lines if --tail is set,
+// files are seeked and compressed files or stdin are read to the end keeping the last lines
+func openTail(name string) (io.ReadCloser, error) {
+	r, err := openInput(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if tailLines < 0 {
+		return r, nil
+	}
+
+	if f, ok := r.(*os.File); ok {
+		err = seekLastLines(f, tailLines)
+		if err != nil {
+			r.Close()
+			return nil, errors.Wrap(err, name)
+		}
+
+		return r, nil
+	}
+
+	defer r.Close()
+
+	lines, err := lastLines(r, tailLines)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	return io.NopCloser(bytes.NewReader(lines)), nil
+}
+
+// lastLines reads r to the end and returns its last n lines, only n lines are kept in memory
+func lastLines(r io.Reader, n int) ([]byte, error) {
+	if n <= 0 {
+		_, err := io.Copy(io.Discard, r)
+		return nil, err
+	}
+
+	ring := make([][]byte, n)
+	count := 0
+	br := bufio.NewReader(r)
+
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			ring[count%n] = line
+			count++
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+
+	start := 0
+	if count > n {
+		start = count - n
+	}
+
+	for i := start; i < count; i++ {
+		buf.Write(ring[i%n])
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mergeFiles interleaves all files chronologically, every line is prefixed with its file name.
+// Files which could not be read are reported to errW and the remaining files are merged anyway.
+func mergeFiles(converter *humanlog.Converter, names []string, w, errW io.Writer) error {
+	color, err := useColor(w)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]humanlog.Source, 0, len(names))
+	failed := 0
+
+	for _, name := range names {
+		r, err := openTail(name)
+		if err != nil {
+			fmt.Fprintf(errW, "Error: %v\n", err)
+			failed++
+			continue
+		}
+		defer r.Close()
+
+		label := filepath.Base(name)
+		if name == stdinName {
+			label = "stdin"
+		}
+
+		sources = append(sources, humanlog.Source{Label: label, Reader: r})
+	}
+
+	merger := &humanlog.Merger{
+		Converter: converter,
+		Tolerance: mergeTolerance,
+		Color:     color,
+	}
+
+	err = merger.Merge(sources, w)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d files could not be processed", failed, len(names))
+	}
+
+	return nil
+}
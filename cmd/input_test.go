@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "files and stdin in order",
+			args: []string{"b.json", "-", "a.json"},
+			want: []string{"b.json", "-", "a.json"},
+		},
+		{
+			name: "glob",
+			args: []string{filepath.Join("..", "test-uber-zap*.json")},
+			want: []string{filepath.Join("..", "test-uber-zap-with-error.json"), filepath.Join("..", "test-uber-zap.json")},
+		},
+		{
+			name:    "no match",
+			args:    []string{filepath.Join("..", "*.missing")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			args:    []string{"[.json"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expandArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_filesToHumanReadable(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		tail     int
+		merge    bool
+		wantW    string
+		wantErrW string
+		wantErr  bool
+	}{
+		{
+			name:  "files in order",
+			args:  []string{filepath.Join("..", "test-spring-boot.json"), filepath.Join("..", "test-quarkus.json")},
+			tail:  -1,
+			wantW: "INFO 2020-07-15T19:09:39.983Z\torg.acme.MyClass\tMy log message\nINFO 2020-07-14T09:38:14.977Z\torg.acme.MyClass\tsample output\n",
+		},
+		{
+			name:     "missing file is reported and skipped",
+			args:     []string{filepath.Join("..", "missing.json"), filepath.Join("..", "test-quarkus.json")},
+			tail:     -1,
+			wantW:    "INFO 2020-07-14T09:38:14.977Z\torg.acme.MyClass\tsample output\n",
+			wantErrW: "Error: open ../missing.json: no such file or directory\n",
+			wantErr:  true,
+		},
+		{
+			name:  "tail",
+			args:  []string{filepath.Join("..", "test-quarkus.json")},
+			tail:  0,
+			wantW: "",
+		},
+		{
+			name:  "merge",
+			args:  []string{filepath.Join("..", "test-spring-boot.json"), filepath.Join("..", "test-quarkus.json")},
+			tail:  -1,
+			merge: true,
+			wantW: "test-quarkus.json     INFO 2020-07-14T09:38:14.977Z\torg.acme.MyClass\tsample output\n" +
+				"test-spring-boot.json INFO 2020-07-15T19:09:39.983Z\torg.acme.MyClass\tMy log message\n",
+		},
+		{
+			name:     "merge missing file is reported and skipped",
+			args:     []string{filepath.Join("..", "missing.json"), filepath.Join("..", "test-quarkus.json")},
+			tail:     -1,
+			merge:    true,
+			wantW:    "test-quarkus.json INFO 2020-07-14T09:38:14.977Z\torg.acme.MyClass\tsample output\n",
+			wantErrW: "Error: open ../missing.json: no such file or directory\n",
+			wantErr:  true,
+		},
+		{
+			name:  "merge tail",
+			args:  []string{filepath.Join("..", "test-spring-boot.json"), filepath.Join("..", "test-quarkus.json")},
+			tail:  0,
+			merge: true,
+			wantW: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tailLines = tt.tail
+			mergeInput = tt.merge
+			defer func() {
+				tailLines = -1
+				mergeInput = false
+			}()
+
+			w := &bytes.Buffer{}
+			errW := &bytes.Buffer{}
+			err := filesToHumanReadable(tt.args, w, errW)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("filesToHumanReadable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantW, w.String())
+			assert.Equal(t, tt.wantErrW, errW.String())
+		})
+	}
+}
+
+func Test_openTail(t *testing.T) {
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "app.json")
+	assert.NoError(t, os.WriteFile(plain, []byte("a\nb\nc\n"), 0o600))
+
+	compressed := filepath.Join(dir, "app.json.gz")
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte("a\nb\nc"))
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+	assert.NoError(t, os.WriteFile(compressed, buf.Bytes(), 0o600))
+
+	tests := []struct {
+		name string
+		file string
+		tail int
+		want string
+	}{
+		{name: "all lines", file: compressed, tail: -1, want: "a\nb\nc"},
+		{name: "file", file: plain, tail: 2, want: "b\nc\n"},
+		{name: "compressed file", file: compressed, tail: 2, want: "b\nc"},
+		{name: "more lines than available", file: compressed, tail: 5, want: "a\nb\nc"},
+		{name: "zero lines", file: compressed, tail: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tailLines = tt.tail
+			defer func() { tailLines = -1 }()
+
+			r, err := openTail(tt.file)
+			assert.NoError(t, err)
+			defer r.Close()
+
+			got, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -17,12 +17,13 @@ limitations under the License.
 package cmd
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/fhopfensperger/json-log-to-human-readable/pkg/humanlog"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -30,25 +31,50 @@ import (
 var springBootInput bool
 var uberZapInput bool
 var dotnetInput bool
+var inputFormat string
+var showFields bool
+var includeFields []string
+var excludeFields []string
+var minLevel string
+var levels []string
+var colorMode string
+var outputTemplate string
+var timeFormat string
+var timeZone string
+var followInput bool
+var tailLines int
+var mergeInput bool
+var mergeTolerance time.Duration
+var maxLineSize int
 
-var globalUsage = `A simple command line utility to transform one line json log message to a human readable output for example:
+// errNoInput is returned if neither file arguments nor piped input are given
+var errNoInput = errors.New("Input must be pipe or file")
+
+var globalUsage = `A simple command line utility to transform one line json log message to a human readable output.
+The log format is detected automatically for each line, use --format to force a format, for example:
 
 content test.json: { "level": "INFO", "timestamp": "2020-07-14T09:38:14.977Z", "message": "sample output" }
+json-log-to-human-readable test.json
+json-log-to-human-readable 'logs/*.json' other.json
 cat test.json | json-log-to-human-readable
 tail -f test.json | json-log-to-human-readable
 kubectl logs -f -n default pod-name-1 | json-log-to-human-readable`
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "json-log-to-human-readable",
+	Use:   "json-log-to-human-readable [file...]",
 	Short: "Transforms json log message to a human readable output",
 	Long:  globalUsage,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runCommand()
+		// only missing input needs the usage, not errors while processing the input
+		err := runCommand(args)
+		cmd.SilenceUsage = err != errNoInput
+
+		return err
 	},
-	Args: noArgs,
+	Args: cobra.ArbitraryArgs,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -56,7 +82,9 @@ var rootCmd = &cobra.Command{
 func Execute(version string) {
 	rootCmd.Version = version
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(globalUsage)
+		if !rootCmd.SilenceUsage {
+			fmt.Println(globalUsage)
+		}
 		os.Exit(1)
 	}
 }
@@ -65,27 +93,44 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&dotnetInput, "dotnet", "d", false, ".NET JSON input")
 	rootCmd.PersistentFlags().BoolVarP(&springBootInput, "springboot", "s", false, "Spring Boot JSON input")
 	rootCmd.PersistentFlags().BoolVarP(&uberZapInput, "zap", "z", false, "Uber zap JSON Input")
+	rootCmd.PersistentFlags().StringVar(&inputFormat, "format", "auto", "JSON input format: auto or one of "+strings.Join(formatNames(), ", "))
+	rootCmd.PersistentFlags().BoolVarP(&showFields, "fields", "f", false, "Show additional fields as key=value pairs after the message")
+	rootCmd.PersistentFlags().StringSliceVar(&includeFields, "include-fields", nil, "Only show the given additional fields, nested fields are addressed with dot paths e.g. State.Message")
+	rootCmd.PersistentFlags().StringSliceVar(&excludeFields, "exclude-fields", nil, "Hide the given additional fields, nested fields are addressed with dot paths e.g. State.Message")
+	rootCmd.PersistentFlags().StringVar(&minLevel, "min-level", "", "Hide all messages below this level: trace, debug, info, warn, error or fatal")
+	rootCmd.PersistentFlags().StringSliceVar(&levels, "levels", nil, "Only show messages with exactly these levels, e.g. warn,error")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "Colorize the output: auto, always or never. auto disables colors if the output is no terminal or NO_COLOR is set")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go text/template for every message or one of the presets compact, short, verbose or message, e.g. '{{.Time | fmt \"15:04:05\"}} {{.Level | pad 5}} {{.Message}} {{.Fields}}', .Fields only contains the fields a format always shows like zap controller and request unless -f or --include-fields is set")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", "", "Format of the timestamps: RFC3339, RFC3339Nano, kitchen, datetime, time, relative, delta or a Go time layout e.g. 15:04:05.000")
+	rootCmd.PersistentFlags().StringVar(&timeZone, "tz", "", "Time zone of the timestamps: UTC, Local or an IANA name e.g. Europe/Berlin. The time zone of the log message is used if not set")
+	rootCmd.PersistentFlags().BoolVarP(&followInput, "follow", "F", false, "Keep reading appended lines of the file arguments, rotated or truncated files are reopened")
+	rootCmd.PersistentFlags().IntVarP(&tailLines, "tail", "n", -1, "Only show the last n lines of the file arguments, all lines are shown if negative")
+	rootCmd.PersistentFlags().BoolVarP(&mergeInput, "merge", "m", false, "Merge the file arguments chronologically, every line is prefixed with the file name")
+	rootCmd.PersistentFlags().DurationVar(&mergeTolerance, "merge-tolerance", time.Second, "How far lines within a single file may be out of order when merging")
+	rootCmd.PersistentFlags().IntVar(&maxLineSize, "max-line-size", 0, "Truncate lines longer than this number of bytes instead of parsing them, 0 means no limit")
 	rootCmd.SetVersionTemplate(`{{printf "v%s\n" .Version}}`)
 }
 
-func noArgs(cmd *cobra.Command, args []string) error {
+// runCommand converts the given files in order, stdin is used if no file is given
+func runCommand(args []string) error {
 	if len(args) > 0 {
-		return errors.Errorf(
-			"%q accepts no arguments\n\nUsage:  %s",
-			cmd.CommandPath(),
-			cmd.UseLine(),
-		)
+		return filesToHumanReadable(args, os.Stdout, os.Stderr)
 	}
 
-	return nil
-}
+	if followInput {
+		return errors.New("--follow requires file arguments")
+	}
 
-func runCommand() error {
 	if isInputFromPipe() {
-		return toHumanReadable(os.Stdin, os.Stdout)
+		r, err := decompressStream(io.NopCloser(os.Stdin))
+		if err != nil {
+			return err
+		}
+
+		return toHumanReadable(r, os.Stdout)
 	}
 
-	return errors.New("Input must be pipe")
+	return errNoInput
 }
 
 func isInputFromPipe() bool {
@@ -97,58 +142,167 @@ func isInputFromPipe() bool {
 	return fileInfo.Mode()&os.ModeCharDevice == 0
 }
 
+// isTerminal reports whether w is a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fileInfo.Mode()&os.ModeCharDevice != 0
+}
+
 func toHumanReadable(r io.Reader, w io.Writer) error {
-	scanner := bufio.NewScanner(bufio.NewReader(r))
-	buf := make([]byte, 0, 64*1024) //nolint:gomnd // only used once
-	// increase max buffer size to process large log messages
-	scanner.Buffer(buf, 1024*1024) //nolint:gomnd // only used once
-
-	for scanner.Scan() {
-		byteValue := scanner.Bytes()
-
-		switch {
-		case uberZapInput:
-			var logMessage GoZapLogMessage
-
-			err := json.Unmarshal(byteValue, &logMessage)
-			if err != nil {
-				fmt.Fprintln(w, string(byteValue))
-				continue
-			}
-
-			logMessage.transform(w)
-		case springBootInput:
-			var logMessage SpringBootLogMessage
-
-			err := json.Unmarshal(byteValue, &logMessage)
-			if err != nil {
-				fmt.Fprintln(w, string(byteValue))
-				continue
-			}
-
-			logMessage.transform(w)
-		case dotnetInput:
-			var logMessage DotNetLogMessage
-
-			err := json.Unmarshal(byteValue, &logMessage)
-			if err != nil {
-				fmt.Fprintln(w, string(byteValue))
-				continue
-			}
-
-			logMessage.transform(w)
-		default:
-			var logMessage QuarkusLogMessage
-
-			err := json.Unmarshal(byteValue, &logMessage)
-			if err != nil {
-				fmt.Fprintln(w, string(byteValue))
-				continue
-			}
-
-			logMessage.transform(w)
+	converter, err := newConverter(w)
+	if err != nil {
+		return err
+	}
+
+	return converter.Convert(r, w)
+}
+
+// newConverter returns a converter configured by the flags writing to w
+func newConverter(w io.Writer) (*humanlog.Converter, error) {
+	converter := humanlog.NewConverter()
+
+	parser, err := selectedParser()
+	if err != nil {
+		return nil, err
+	}
+
+	converter.Parser = parser
+	converter.MaxLineSize = maxLineSize
+	converter.Fields = humanlog.FieldSelector{
+		Show:    showFields,
+		Include: includeFields,
+		Exclude: excludeFields,
+	}
+
+	converter.Levels, err = selectedLevels()
+	if err != nil {
+		return nil, err
+	}
+
+	color, err := useColor(w)
+	if err != nil {
+		return nil, err
+	}
+
+	formatter, err := selectedTimeFormatter()
+	if err != nil {
+		return nil, err
+	}
+
+	converter.Renderer, err = selectedRenderer(color, formatter)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter, nil
+}
+
+// selectedParser returns the parser for the format forced by a flag,
+// or detects the format of every line if no flag is set
+func selectedParser() (humanlog.Parser, error) {
+	switch {
+	case uberZapInput:
+		return humanlog.FormatByName("zap")
+	case springBootInput:
+		return humanlog.FormatByName("springboot")
+	case dotnetInput:
+		return humanlog.FormatByName("dotnet")
+	case inputFormat != "" && inputFormat != "auto":
+		return humanlog.FormatByName(inputFormat)
+	default:
+		return humanlog.AutoParser{}, nil
+	}
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(humanlog.Formats))
+	for _, f := range humanlog.Formats {
+		names = append(names, f.Name)
+	}
+
+	return names
+}
+
+// selectedLevels returns the level filter configured by --min-level and --levels
+func selectedLevels() (humanlog.LevelFilter, error) {
+	filter := humanlog.LevelFilter{}
+
+	if minLevel != "" {
+		filter.Min = humanlog.ParseLevel(minLevel)
+		if filter.Min == humanlog.LevelUnknown {
+			return filter, errors.Errorf("unknown level %q", minLevel)
+		}
+	}
+
+	for _, name := range levels {
+		level := humanlog.ParseLevel(name)
+		if level == humanlog.LevelUnknown {
+			return filter, errors.Errorf("unknown level %q", name)
 		}
+
+		filter.Levels = append(filter.Levels, level)
+	}
+
+	return filter, nil
+}
+
+// useColor decides by --color whether the output written to w is colored
+func useColor(w io.Writer) (bool, error) {
+	switch colorMode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		return os.Getenv("NO_COLOR") == "" && isTerminal(w), nil
+	default:
+		return false, errors.Errorf("invalid color mode %q, must be auto, always or never", colorMode)
+	}
+}
+
+// selectedRenderer returns the renderer for --template or the plain text renderer
+func selectedRenderer(color bool, formatter *humanlog.TimeFormatter) (humanlog.Renderer, error) {
+	if outputTemplate == "" {
+		return humanlog.TextRenderer{Color: color, Time: formatter}, nil
+	}
+
+	renderer, err := humanlog.NewTemplateRenderer(outputTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer.Color = color
+	renderer.Time = formatter
+
+	return renderer, nil
+}
+
+// selectedTimeFormatter returns the time formatter for --time-format and --tz,
+// nil if both are not set
+func selectedTimeFormatter() (*humanlog.TimeFormatter, error) {
+	if timeFormat == "" && timeZone == "" {
+		return nil, nil
+	}
+
+	formatter := &humanlog.TimeFormatter{Layout: humanlog.TimeLayout(timeFormat)}
+
+	if timeZone != "" {
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid time zone %q", timeZone)
+		}
+
+		formatter.Location = location
 	}
 
-	return scanner.Err()
+	return formatter, nil
 }
@@ -8,48 +8,10 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/spf13/cobra"
+	"github.com/fhopfensperger/json-log-to-human-readable/pkg/humanlog"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_noArgs(t *testing.T) {
-	type args struct {
-		cmd  *cobra.Command
-		args []string
-	}
-
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "to many args",
-			args: args{
-				cmd:  rootCmd,
-				args: []string{"arg1"},
-			},
-			wantErr: true,
-		},
-		{
-			name: "ok",
-			args: args{
-				cmd:  rootCmd,
-				args: []string{},
-			},
-			wantErr: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if err := noArgs(tt.args.cmd, tt.args.args); (err != nil) != tt.wantErr {
-				t.Errorf("noArgs() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
 // dummy test
 func Test_isInputFromPipe(t *testing.T) {
 	tests := []struct {
@@ -70,20 +32,31 @@ func Test_isInputFromPipe(t *testing.T) {
 	}
 }
 
-// dummy test
 func Test_runCommand(t *testing.T) {
 	tests := []struct {
 		name    string
+		args    []string
 		wantErr bool
 	}{
 		{
-			name:    "",
+			name:    "no pipe and no file",
+			args:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "file",
+			args:    []string{filepath.Join("..", "test-quarkus.json")},
+			wantErr: false,
+		},
+		{
+			name:    "missing file",
+			args:    []string{filepath.Join("..", "missing.json")},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := runCommand(); (err != nil) != tt.wantErr {
+			if err := runCommand(tt.args); (err != nil) != tt.wantErr {
 				t.Errorf("runCommand() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -101,6 +74,25 @@ func TestExecute(t *testing.T) {
 	assert.Equal(t, "v0.0.0\n", string(out))
 }
 
+func TestRootCmd_processingErrorWithoutUsage(t *testing.T) {
+	defer func() { rootCmd.SilenceUsage = false }()
+
+	// TestExecute leaves the version flag set
+	if flag := rootCmd.Flags().Lookup("version"); flag != nil {
+		assert.NoError(t, flag.Value.Set("false"))
+	}
+
+	b := bytes.NewBufferString("")
+	rootCmd.SetOut(b)
+	rootCmd.SetErr(b)
+	rootCmd.SetArgs([]string{filepath.Join("..", "missing.json")})
+
+	err := rootCmd.Execute()
+	assert.Error(t, err)
+	assert.True(t, rootCmd.SilenceUsage)
+	assert.NotContains(t, b.String(), "Usage:")
+}
+
 func Test_toHumanReadable(t *testing.T) {
 	quarkus, _ := os.Open(filepath.Join("..", "test-quarkus.json"))
 	uber, _ := os.Open(filepath.Join("..", "test-uber-zap.json"))
@@ -132,7 +124,7 @@ func Test_toHumanReadable(t *testing.T) {
 		{
 			name:    "uber zap",
 			args:    args{uber},
-			wantW:   "information 2020-08-26 12:45:05.143377065 +0000 UTC\tcontroller-runtime.controller\tmsg: Reconciler error\tcontroller: scaledobject-controller\trequest: default/azure-servicebus-queue-scaledobject\n",
+			wantW:   "INFO 2020-08-26T12:45:05.143Z\tcontroller-runtime.controller\tReconciler error\tcontroller=scaledobject-controller request=default/azure-servicebus-queue-scaledobject\n",
 			wantErr: false,
 		},
 		{
@@ -156,7 +148,7 @@ func Test_toHumanReadable(t *testing.T) {
 		{
 			name:    "dotnet",
 			args:    args{dotnet},
-			wantW:   "Information 2021-03-19T13:01:52.734Z\tUserManagementSvc.Authorization.UserRealmRoleAuthorizationHandler\tRole authorization requirement satisfied\n",
+			wantW:   "INFO 2021-03-19T13:01:52.734Z\tUserManagementSvc.Authorization.UserRealmRoleAuthorizationHandler\tRole authorization requirement satisfied\n",
 			wantErr: false,
 		},
 		{
@@ -165,10 +157,105 @@ func Test_toHumanReadable(t *testing.T) {
 			wantW:   "123\n",
 			wantErr: false,
 		},
+		{
+			name:    "fields",
+			args:    args{strings.NewReader(`{"@timestamp":"2020-07-15T19:09:39.983Z","@version":"1","message":"My log message","logger_name":"org.acme.MyClass","thread_name":"pool-1-thread-1","level":"INFO","level_value":20000}`)},
+			wantW:   "INFO 2020-07-15T19:09:39.983Z\torg.acme.MyClass\tMy log message\tthread_name=pool-1-thread-1\n",
+			wantErr: false,
+		},
+		{
+			name: "min level",
+			args: args{strings.NewReader(`{"level":"debug","ts":1598445905.143377,"logger":"controller","msg":"zap debug"}
+{"level":"dpanic","ts":1598445905.143377,"logger":"controller","msg":"zap dpanic"}
+{"@timestamp":"2020-07-15T19:09:39.983Z","message":"spring info","logger_name":"org.acme.MyClass","level":"INFO","level_value":20000}
+{"Timestamp":"2021-03-19T13:01:52.734Z","LogLevel":"Critical","Category":"UserManagementSvc","Message":"dotnet critical"}
+plain text`)},
+			wantW: "ERROR 2020-08-26T12:45:05.143Z\tcontroller\tzap dpanic\n" +
+				"FATAL 2021-03-19T13:01:52.734Z\tUserManagementSvc\tdotnet critical\n" +
+				"plain text\n",
+			wantErr: false,
+		},
+		{
+			name:    "unknown min level",
+			args:    args{strings.NewReader("123")},
+			wantW:   "",
+			wantErr: true,
+		},
+		{
+			name:    "template",
+			args:    args{strings.NewReader(`{"level":"info","ts":1598445905.143377,"logger":"controller","msg":"zap message"}`)},
+			wantW:   "12:45:05 INFO  zap message\n",
+			wantErr: false,
+		},
+		{
+			name:    "invalid template",
+			args:    args{strings.NewReader("123")},
+			wantW:   "",
+			wantErr: true,
+		},
+		{
+			name:    "time format",
+			args:    args{strings.NewReader(`{"level":"INFO","timestamp":"2020-07-14T09:38:14.977Z","message":"sample output","loggerName":"org.acme.MyClass"}`)},
+			wantW:   "INFO 2020-07-14 11:38:14\torg.acme.MyClass\tsample output\n",
+			wantErr: false,
+		},
+		{
+			name:    "invalid time zone",
+			args:    args{strings.NewReader("123")},
+			wantW:   "",
+			wantErr: true,
+		},
+		{
+			name:    "max line size",
+			args:    args{strings.NewReader(`{"level":"INFO","message":"sample output"}`)},
+			wantW:   "{\"level\":\" … [truncated 32 bytes]\n",
+			wantErr: false,
+		},
+		{
+			name:    "format",
+			args:    args{strings.NewReader(`{"level":"error","msg":"failed","error":"connection refused"}`)},
+			wantW:   "ERROR \t\tfailed\nerror: connection refused\n",
+			wantErr: false,
+		},
+		{
+			name:    "unknown format",
+			args:    args{strings.NewReader("123")},
+			wantW:   "",
+			wantErr: true,
+		},
+		{
+			name: "auto detect mixed input",
+			args: args{strings.NewReader(`{"level":"info","ts":1598445905.143377,"logger":"controller","msg":"zap message"}
+{"@timestamp":"2020-07-15T19:09:39.983Z","message":"spring message","logger_name":"org.acme.MyClass","level":"INFO"}
+{"Timestamp":"2021-03-19T13:01:52.734Z","LogLevel":"Information","Category":"UserManagementSvc","Message":"dotnet message"}
+{"level":"INFO","timestamp":"2020-07-14T09:38:14.977Z","message":"quarkus message","loggerName":"org.acme.MyClass"}
+{"level":"info","msg":"logrus message","time":"2021-03-19T13:01:52Z"}
+{"time":"2023-08-04T16:09:59Z","level":"WARN+1","source":{"file":"main.go","line":7},"msg":"slog message"}
+plain text`)},
+			wantW: "INFO 2020-08-26T12:45:05.143Z\tcontroller\tzap message\n" +
+				"INFO 2020-07-15T19:09:39.983Z\torg.acme.MyClass\tspring message\n" +
+				"INFO 2021-03-19T13:01:52.734Z\tUserManagementSvc\tdotnet message\n" +
+				"INFO 2020-07-14T09:38:14.977Z\torg.acme.MyClass\tquarkus message\n" +
+				"INFO 2021-03-19T13:01:52.000Z\t\tlogrus message\n" +
+				"WARN 2023-08-04T16:09:59.000Z\tmain.go:7\tslog message\n" +
+				"plain text\n",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			showFields = false
+			includeFields = nil
+			excludeFields = nil
+			minLevel = ""
+			levels = nil
+			outputTemplate = ""
+			timeFormat = ""
+			timeZone = ""
+			maxLineSize = 0
+			inputFormat = "auto"
+
 			switch tt.name {
 			case "uber zap":
 				uberZapInput = true
@@ -182,6 +269,58 @@ func Test_toHumanReadable(t *testing.T) {
 				uberZapInput = false
 				dotnetInput = true
 				springBootInput = false
+			case "min level":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				minLevel = "warn"
+			case "unknown min level":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				minLevel = "loud"
+			case "template":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				outputTemplate = "compact"
+			case "invalid template":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				outputTemplate = "{{.Message"
+			case "time format":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				timeFormat = "datetime"
+				timeZone = "Europe/Berlin"
+			case "invalid time zone":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				timeZone = "Mars/Olympus_Mons"
+			case "max line size":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				maxLineSize = 10
+			case "format":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				inputFormat = "logrus"
+			case "unknown format":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				inputFormat = "log4net"
+			case "fields":
+				uberZapInput = false
+				dotnetInput = false
+				springBootInput = false
+				showFields = true
+				excludeFields = []string{"@version"}
 			default:
 				uberZapInput = false
 				dotnetInput = false
@@ -199,3 +338,100 @@ func Test_toHumanReadable(t *testing.T) {
 		})
 	}
 }
+
+func Test_selectedLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		minLevel string
+		levels   []string
+		want     humanlog.LevelFilter
+		wantErr  bool
+	}{
+		{
+			name: "no filter",
+			want: humanlog.LevelFilter{},
+		},
+		{
+			name:     "min level",
+			minLevel: "Warning",
+			want:     humanlog.LevelFilter{Min: humanlog.LevelWarn},
+		},
+		{
+			name:   "exact levels",
+			levels: []string{"error", "Critical"},
+			want:   humanlog.LevelFilter{Levels: []humanlog.Level{humanlog.LevelError, humanlog.LevelFatal}},
+		},
+		{
+			name:    "unknown level",
+			levels:  []string{"loud"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minLevel = tt.minLevel
+			levels = tt.levels
+			defer func() {
+				minLevel = ""
+				levels = nil
+			}()
+
+			got, err := selectedLevels()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("selectedLevels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_useColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		colorMode string
+		noColor   string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "always",
+			colorMode: "always",
+			noColor:   "1",
+			want:      true,
+		},
+		{
+			name:      "never",
+			colorMode: "never",
+			want:      false,
+		},
+		{
+			name:      "auto without terminal",
+			colorMode: "auto",
+			want:      false,
+		},
+		{
+			name:      "invalid",
+			colorMode: "sometimes",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colorMode = tt.colorMode
+			defer func() { colorMode = "auto" }()
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			got, err := useColor(&bytes.Buffer{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("useColor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
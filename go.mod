@@ -3,9 +3,11 @@ module github.com/fhopfensperger/json-log-to-human-readable
 go 1.17
 
 require (
+	github.com/klauspost/compress v1.15.15
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/cobra v1.9.0
 	github.com/stretchr/testify v1.10.0
+	github.com/ulikunitz/xz v0.5.15
 )
 
 require (
@@ -0,0 +1,152 @@
+package humanlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultClefLevel is the level of CLEF events without "@l"
+const defaultClefLevel = "Information"
+
+// ClefLogMessage Serilog compact log event format (CLEF) log message type,
+// all other keys are the properties of the message template
+type ClefLogMessage struct {
+	Timestamp string `json:"@t"`
+	// MessageTemplate is rendered with the properties if the rendered message is missing
+	MessageTemplate string `json:"@mt,omitempty"`
+	Message         string `json:"@m,omitempty"`
+	Level           string `json:"@l,omitempty"`
+	Exception       string `json:"@x,omitempty"`
+	TraceID         string `json:"@tr,omitempty"`
+	SpanID          string `json:"@sp,omitempty"`
+	SourceContext   string `json:"SourceContext,omitempty"`
+
+	properties map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes the event and keeps all keys as template properties
+func (clm *ClefLogMessage) UnmarshalJSON(data []byte) error {
+	type event ClefLogMessage
+
+	err := json.Unmarshal(data, (*event)(clm))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &clm.properties)
+}
+
+func (clm *ClefLogMessage) toEntry() *Entry {
+	level := clm.Level
+	if level == "" {
+		level = defaultClefLevel
+	}
+
+	e := &Entry{
+		Time:     parseTime(clm.Timestamp),
+		RawTime:  clm.Timestamp,
+		Level:    ParseLevel(level),
+		RawLevel: level,
+		Logger:   clm.SourceContext,
+		Message:  clm.Message,
+		Trace:    Tracing{TraceID: clm.TraceID, SpanID: clm.SpanID},
+	}
+
+	if e.Message == "" {
+		e.Message = renderMessageTemplate(clm.MessageTemplate, clm.properties)
+	}
+
+	// log message contains an exception
+	if clm.Exception != "" {
+		e.Error = &Error{Stacktrace: clm.Exception}
+	}
+
+	return e
+}
+
+// isClef reports whether the keys belong to a CLEF event
+func isClef(keys map[string]json.RawMessage) bool {
+	return hasKeys(keys, "@t") && (hasKeys(keys, "@mt") || hasKeys(keys, "@m"))
+}
+
+// renderMessageTemplate replaces the holes of a message template like "Hello {Name}"
+// with the property values, holes without a property are kept
+func renderMessageTemplate(template string, properties map[string]json.RawMessage) string {
+	var b strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+
+		switch {
+		case c == '{' && strings.HasPrefix(template[i:], "{{"), c == '}' && strings.HasPrefix(template[i:], "}}"):
+			b.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				b.WriteString(template[i:])
+				return b.String()
+			}
+
+			hole := template[i : i+end+1]
+			b.WriteString(renderHole(hole, properties))
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// renderHole renders a single hole like {Name}, {@Name}, {Name,10} or {Name:l}
+func renderHole(hole string, properties map[string]json.RawMessage) string {
+	name := strings.TrimLeft(hole[1:len(hole)-1], "@$")
+
+	format := ""
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		name, format = name[:i], name[i+1:]
+	}
+
+	alignment := 0
+	if i := strings.IndexByte(name, ','); i >= 0 {
+		alignment, _ = strconv.Atoi(name[i+1:])
+		name = name[:i]
+	}
+
+	raw, ok := properties[name]
+	if !ok {
+		return hole
+	}
+
+	value := propertyValue(raw, format)
+
+	// a positive alignment pads on the left, a negative one on the right
+	return fmt.Sprintf("%*s", alignment, value)
+}
+
+// propertyValue renders a json property like Serilog, strings are quoted unless the literal format "l" is used
+func propertyValue(raw json.RawMessage, format string) string {
+	var s string
+
+	err := json.Unmarshal(raw, &s)
+	if err == nil {
+		if format == "l" {
+			return s
+		}
+
+		return strconv.Quote(s)
+	}
+
+	var b bytes.Buffer
+
+	err = json.Compact(&b, raw)
+	if err != nil {
+		return string(raw)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClefLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Entry
+	}{
+		{
+			name: "message template",
+			line: `{"@t":"2016-06-07T03:44:57.8532799Z","@mt":"Hello, {@User} from {City}","User":{"Name":"nblumhardt","Id":101},"City":"Paris","SourceContext":"App.Program"}`,
+			want: &Entry{
+				Time:     time.Date(2016, 6, 7, 3, 44, 57, 853279900, time.UTC),
+				RawTime:  "2016-06-07T03:44:57.8532799Z",
+				Level:    LevelInfo,
+				RawLevel: "Information",
+				Logger:   "App.Program",
+				Message:  `Hello, {"Name":"nblumhardt","Id":101} from "Paris"`,
+			},
+		},
+		{
+			name: "rendered message with exception",
+			line: `{"@t":"2016-06-07T03:44:57Z","@mt":"Disk {Drive} failed","@m":"Disk \"C\" failed","@l":"Error","Drive":"C","@x":"System.IO.IOException: failed\n   at App.Program.Main()","@tr":"abc","@sp":"def"}`,
+			want: &Entry{
+				Time:     time.Date(2016, 6, 7, 3, 44, 57, 0, time.UTC),
+				RawTime:  "2016-06-07T03:44:57Z",
+				Level:    LevelError,
+				RawLevel: "Error",
+				Message:  `Disk "C" failed`,
+				Error:    &Error{Stacktrace: "System.IO.IOException: failed\n   at App.Program.Main()"},
+				Trace:    Tracing{TraceID: "abc", SpanID: "def"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clm := &ClefLogMessage{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.line), clm))
+
+			got := clm.toEntry()
+			assert.True(t, tt.want.Time.Equal(got.Time))
+			got.Time = tt.want.Time
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_renderMessageTemplate(t *testing.T) {
+	properties := map[string]json.RawMessage{
+		"Name":    json.RawMessage(`"Alice"`),
+		"Count":   json.RawMessage(`42`),
+		"Items":   json.RawMessage(`[1, 2]`),
+		"0":       json.RawMessage(`"first"`),
+		"Elapsed": json.RawMessage(`12.5`),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "string", template: "Hello {Name}", want: `Hello "Alice"`},
+		{name: "literal", template: "Hello {Name:l}", want: "Hello Alice"},
+		{name: "number and array", template: "{Count} of {$Items}", want: "42 of [1,2]"},
+		{name: "positional", template: "{0} wins", want: `"first" wins`},
+		{name: "format is ignored", template: "took {Elapsed:0.00} ms", want: "took 12.5 ms"},
+		{name: "alignment", template: "[{Count,4}] [{Count,-4}]", want: "[  42] [42  ]"},
+		{name: "escaped braces", template: "{{Name}} is {Name}", want: `{Name} is "Alice"`},
+		{name: "missing property", template: "Hello {Unknown}", want: "Hello {Unknown}"},
+		{name: "unclosed hole", template: "Hello {Name", want: "Hello {Name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, renderMessageTemplate(tt.template, properties))
+		})
+	}
+}
+
+func TestClef_Parse(t *testing.T) {
+	line := `{"@t":"2016-06-07T03:44:57Z","@mt":"Hello {Name}","@i":"a1e77001","Name":"Alice"}`
+
+	got, err := AutoParser{}.Parse([]byte(line))
+	assert.NoError(t, err)
+	assert.Equal(t, "clef", got.Format)
+	assert.Equal(t, `Hello "Alice"`, got.Message)
+	assert.Equal(t, Fields{"@i": "a1e77001", "Name": "Alice"}, got.Fields)
+}
@@ -0,0 +1,215 @@
+package humanlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// lambdaPlatformPrefix starts the type of the Lambda platform events in json format
+const lambdaPlatformPrefix = "platform."
+
+// lambdaReportPattern matches the metrics of a Lambda REPORT line, e.g. "Duration: 2.35 ms"
+var lambdaReportPattern = regexp.MustCompile(`(Init Duration|Billed Duration|Duration|Memory Size|Max Memory Used): ([0-9.]+) (ms|MB)`)
+
+// CloudWatchLogMessage AWS CloudWatch Logs event as exported by the aws cli or a Lambda log message,
+// the message of an exported event is decoded with all formats again
+type CloudWatchLogMessage struct {
+	// Timestamp is epoch milliseconds for exported events and a RFC3339 string for Lambda
+	Timestamp json.RawMessage `json:"timestamp,omitempty"`
+	// Message is a string or an object if Lambda logged a structured message
+	Message   json.RawMessage `json:"message,omitempty"`
+	Level     string          `json:"level,omitempty"`
+	RequestID string          `json:"requestId,omitempty"`
+	// platform events of Lambda in json format
+	Time   string        `json:"time,omitempty"`
+	Type   string        `json:"type,omitempty"`
+	Record *LambdaRecord `json:"record,omitempty"`
+}
+
+// LambdaRecord is the record of a Lambda platform event
+type LambdaRecord struct {
+	RequestID string         `json:"requestId"`
+	Version   string         `json:"version,omitempty"`
+	Status    string         `json:"status,omitempty"`
+	Metrics   *LambdaMetrics `json:"metrics,omitempty"`
+}
+
+// LambdaMetrics are the metrics of a Lambda platform.report event
+type LambdaMetrics struct {
+	DurationMs       float64 `json:"durationMs"`
+	BilledDurationMs float64 `json:"billedDurationMs"`
+	MemorySizeMB     float64 `json:"memorySizeMB"`
+	MaxMemoryUsedMB  float64 `json:"maxMemoryUsedMB"`
+	InitDurationMs   float64 `json:"initDurationMs,omitempty"`
+}
+
+func (cwm *CloudWatchLogMessage) toEntry() *Entry {
+	if cwm.Record != nil {
+		return cwm.platformEntry()
+	}
+
+	var message string
+	if json.Unmarshal(cwm.Message, &message) != nil {
+		// structured message of Lambda
+		var b bytes.Buffer
+		_ = json.Compact(&b, cwm.Message)
+		message = b.String()
+	}
+
+	e := cwm.messageEntry(message)
+
+	if e.Time.IsZero() {
+		e.Time, e.RawTime = parseRawTime(cwm.Timestamp)
+	}
+
+	if e.RawLevel == "" && cwm.Level != "" {
+		e.Level, e.RawLevel = ParseLevel(cwm.Level), cwm.Level
+	}
+
+	if cwm.RequestID != "" {
+		if e.Fields == nil {
+			e.Fields = Fields{}
+		}
+
+		e.Fields["requestId"] = cwm.RequestID
+	}
+
+	return e
+}
+
+// messageEntry decodes the message of an exported event, which is an embedded json log line,
+// a Lambda platform line like START, END or REPORT, a Lambda text line or plain text
+func (cwm *CloudWatchLogMessage) messageEntry(message string) *Entry {
+	trimmed := strings.TrimSpace(message)
+
+	if cwm.Level == "" && strings.HasPrefix(trimmed, "{") {
+		e, err := AutoParser{}.Parse([]byte(trimmed))
+		if err == nil {
+			return e
+		}
+	}
+
+	for _, platform := range []string{"START", "END", "REPORT", "INIT_START"} {
+		if strings.HasPrefix(trimmed, platform+" ") {
+			return lambdaPlatformLine(platform, trimmed)
+		}
+	}
+
+	// Lambda text format: timestamp, request id, level and message separated by tabs
+	parts := strings.SplitN(trimmed, "\t", 4)
+	if len(parts) == 4 && ParseLevel(parts[2]) != LevelUnknown && !parseTime(parts[0]).IsZero() {
+		return &Entry{
+			Time:     parseTime(parts[0]),
+			RawTime:  parts[0],
+			Level:    ParseLevel(parts[2]),
+			RawLevel: parts[2],
+			Message:  parts[3],
+			Fields:   Fields{"requestId": parts[1]},
+		}
+	}
+
+	return &Entry{Message: strings.TrimRight(message, "\n")}
+}
+
+// lambdaPlatformLine parses a line like "REPORT RequestId: 8f5... Duration: 2.35 ms Billed Duration: 3 ms ..."
+func lambdaPlatformLine(platform, line string) *Entry {
+	e := &Entry{
+		Level:    LevelInfo,
+		RawLevel: platform,
+		Message:  platform,
+		Fields:   Fields{},
+	}
+
+	fields := strings.Fields(line)
+	for i := 0; i+1 < len(fields); i++ {
+		switch fields[i] {
+		case "RequestId:":
+			e.Fields["requestId"] = fields[i+1]
+		case "Version:":
+			e.Fields["version"] = fields[i+1]
+		}
+	}
+
+	if platform == "REPORT" {
+		metrics := map[string]string{}
+		for _, match := range lambdaReportPattern.FindAllStringSubmatch(line, -1) {
+			metrics[match[1]] = match[2]
+		}
+
+		e.Message = reportMessage(metrics["Duration"], metrics["Billed Duration"], metrics["Max Memory Used"], metrics["Memory Size"], metrics["Init Duration"])
+	}
+
+	if len(e.Fields) == 0 {
+		e.Fields = nil
+	}
+
+	return e
+}
+
+// platformEntry returns the entry of a Lambda platform event in json format
+func (cwm *CloudWatchLogMessage) platformEntry() *Entry {
+	platform := strings.ToUpper(strings.TrimPrefix(cwm.Type, lambdaPlatformPrefix))
+
+	e := &Entry{
+		Time:     parseTime(cwm.Time),
+		RawTime:  cwm.Time,
+		Level:    LevelInfo,
+		RawLevel: platform,
+		Message:  platform,
+		Fields:   Fields{"requestId": cwm.Record.RequestID},
+	}
+
+	if cwm.Record.Version != "" {
+		e.Fields["version"] = cwm.Record.Version
+	}
+
+	if cwm.Record.Status != "" {
+		e.Fields["status"] = cwm.Record.Status
+	}
+
+	if m := cwm.Record.Metrics; m != nil {
+		e.Message = reportMessage(formatFloat(m.DurationMs), formatFloat(m.BilledDurationMs),
+			formatFloat(m.MaxMemoryUsedMB), formatFloat(m.MemorySizeMB), formatFloat(m.InitDurationMs))
+	}
+
+	return e
+}
+
+// reportMessage renders the metrics of a Lambda invocation, metrics which are not known are empty
+func reportMessage(duration, billed, memoryUsed, memorySize, initDuration string) string {
+	message := fmt.Sprintf("REPORT Duration: %s ms, Billed Duration: %s ms, Memory: %s/%s MB", duration, billed, memoryUsed, memorySize)
+
+	if initDuration != "" {
+		message += ", Init Duration: " + initDuration + " ms"
+	}
+
+	return message
+}
+
+func formatFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(f)
+}
+
+// isCloudWatch reports whether the keys belong to an exported CloudWatch event with a numeric timestamp,
+// a Lambda json log message or a Lambda platform event
+func isCloudWatch(keys map[string]json.RawMessage) bool {
+	if hasKeys(keys, "requestId", "level", "message") {
+		return true
+	}
+
+	var platform string
+	if hasKeys(keys, "type", "record") && json.Unmarshal(keys["type"], &platform) == nil {
+		return strings.HasPrefix(platform, lambdaPlatformPrefix)
+	}
+
+	var timestamp json.Number
+
+	return hasKeys(keys, "timestamp", "message") && !hasKeys(keys, "level") && json.Unmarshal(keys["timestamp"], &timestamp) == nil
+}
@@ -0,0 +1,145 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudWatchLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Entry
+	}{
+		{
+			name: "embedded json",
+			line: `{"timestamp":1699999999100,"message":"{\"level\":\"WARN\",\"timestamp\":\"2023-11-14T22:13:19.1Z\",\"message\":\"slow\",\"loggerName\":\"org.acme.Orders\"}"}`,
+			want: &Entry{
+				Format:   "quarkus",
+				Time:     time.Date(2023, 11, 14, 22, 13, 19, 100000000, time.UTC),
+				RawTime:  "2023-11-14T22:13:19.1Z",
+				Level:    LevelWarn,
+				RawLevel: "WARN",
+				Logger:   "org.acme.Orders",
+				Message:  "slow",
+			},
+		},
+		{
+			name: "plain text",
+			line: `{"timestamp":1699999999100,"message":"hello\n"}`,
+			want: &Entry{
+				Time:    time.Date(2023, 11, 14, 22, 13, 19, 100000000, time.UTC),
+				RawTime: "1699999999100",
+				Message: "hello",
+			},
+		},
+		{
+			name: "lambda text format",
+			line: `{"timestamp":1699999999150,"message":"2023-11-14T22:13:19.150Z\t8f5e-11\tERROR\tfailed\n"}`,
+			want: &Entry{
+				Time:     time.Date(2023, 11, 14, 22, 13, 19, 150000000, time.UTC),
+				RawTime:  "2023-11-14T22:13:19.150Z",
+				Level:    LevelError,
+				RawLevel: "ERROR",
+				Message:  "failed",
+				Fields:   Fields{"requestId": "8f5e-11"},
+			},
+		},
+		{
+			name: "lambda start",
+			line: `{"timestamp":1699999999000,"message":"START RequestId: 8f5e-11 Version: $LATEST\n"}`,
+			want: &Entry{
+				Time:     time.Date(2023, 11, 14, 22, 13, 19, 0, time.UTC),
+				RawTime:  "1699999999000",
+				Level:    LevelInfo,
+				RawLevel: "START",
+				Message:  "START",
+				Fields:   Fields{"requestId": "8f5e-11", "version": "$LATEST"},
+			},
+		},
+		{
+			name: "lambda report",
+			line: `{"timestamp":1699999999200,"message":"REPORT RequestId: 8f5e-11\tDuration: 2.35 ms\tBilled Duration: 3 ms\tMemory Size: 128 MB\tMax Memory Used: 70 MB\tInit Duration: 150.00 ms\t\n"}`,
+			want: &Entry{
+				Time:     time.Date(2023, 11, 14, 22, 13, 19, 200000000, time.UTC),
+				RawTime:  "1699999999200",
+				Level:    LevelInfo,
+				RawLevel: "REPORT",
+				Message:  "REPORT Duration: 2.35 ms, Billed Duration: 3 ms, Memory: 70/128 MB, Init Duration: 150.00 ms",
+				Fields:   Fields{"requestId": "8f5e-11"},
+			},
+		},
+		{
+			name: "lambda json",
+			line: `{"timestamp":"2023-11-14T22:13:19.300Z","level":"INFO","message":{"event":"order", "id":7},"requestId":"8f5e-11"}`,
+			want: &Entry{
+				Time:     time.Date(2023, 11, 14, 22, 13, 19, 300000000, time.UTC),
+				RawTime:  "2023-11-14T22:13:19.300Z",
+				Level:    LevelInfo,
+				RawLevel: "INFO",
+				Message:  `{"event":"order","id":7}`,
+				Fields:   Fields{"requestId": "8f5e-11"},
+			},
+		},
+		{
+			name: "lambda platform report",
+			line: `{"time":"2023-11-14T22:13:19.400Z","type":"platform.report","record":{"requestId":"8f5e-11","metrics":{"durationMs":2.35,"billedDurationMs":3,"memorySizeMB":128,"maxMemoryUsedMB":70},"status":"success"}}`,
+			want: &Entry{
+				Time:     time.Date(2023, 11, 14, 22, 13, 19, 400000000, time.UTC),
+				RawTime:  "2023-11-14T22:13:19.400Z",
+				Level:    LevelInfo,
+				RawLevel: "REPORT",
+				Message:  "REPORT Duration: 2.35 ms, Billed Duration: 3 ms, Memory: 70/128 MB",
+				Fields:   Fields{"requestId": "8f5e-11", "status": "success"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cwm := &CloudWatchLogMessage{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.line), cwm))
+			assert.Equal(t, tt.want, cwm.toEntry())
+		})
+	}
+}
+
+func TestCloudWatch_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantFormat string
+	}{
+		{
+			name:       "exported event",
+			line:       `{"timestamp":1699999999000,"message":"hello","ingestionTime":1700000000000}`,
+			wantFormat: "cloudwatch",
+		},
+		{
+			name:       "lambda json",
+			line:       `{"timestamp":"2023-11-14T22:13:19.300Z","level":"INFO","message":"hello","requestId":"8f5e-11"}`,
+			wantFormat: "cloudwatch",
+		},
+		{
+			name:       "lambda platform event",
+			line:       `{"time":"2023-11-14T22:13:19.400Z","type":"platform.start","record":{"requestId":"8f5e-11","version":"$LATEST"}}`,
+			wantFormat: "cloudwatch",
+		},
+		{
+			name:       "quarkus",
+			line:       `{"timestamp":"2023-11-14T22:13:19.300Z","level":"INFO","message":"hello"}`,
+			wantFormat: "quarkus",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AutoParser{}.Parse([]byte(tt.line))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, got.Format)
+		})
+	}
+}
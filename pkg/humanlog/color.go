@@ -0,0 +1,32 @@
+package humanlog
+
+// ANSI escape sequences used for colored output
+const (
+	colorReset   = "\x1b[0m"
+	colorBold    = "\x1b[1m"
+	colorDim     = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+)
+
+var levelColors = map[Level]string{
+	LevelTrace: colorDim,
+	LevelDebug: colorBlue,
+	LevelInfo:  colorGreen,
+	LevelWarn:  colorYellow,
+	LevelError: colorRed,
+	LevelFatal: colorBold + colorMagenta,
+}
+
+// paint wraps s with the color if enabled, empty strings are never colored
+func paint(enabled bool, color, s string) string {
+	if !enabled || color == "" || s == "" {
+		return s
+	}
+
+	return color + s + colorReset
+}
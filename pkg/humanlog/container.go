@@ -0,0 +1,164 @@
+package humanlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	// criFull marks a complete line of the CRI log format
+	criFull = "F"
+	// criPartial marks a line which is continued in the next line of the same stream
+	criPartial = "P"
+)
+
+// criLinePattern matches a line of the CRI log format used by containerd and CRI-O, e.g.
+// 2023-10-06T00:17:09.669794202Z stdout F {"level":"info"}
+var criLinePattern = regexp.MustCompile(`^(\S+) (stdout|stderr) ([PF]) ?(.*)$`)
+
+// containerRecord is a log line unwrapped from the envelope of a container runtime
+type containerRecord struct {
+	line []byte
+	// stream is stdout or stderr, empty if the line was not wrapped
+	stream string
+	time   time.Time
+}
+
+// dockerLine is a line of the Docker json-file logging driver
+type dockerLine struct {
+	Log    *string `json:"log"`
+	Stream string  `json:"stream"`
+	Time   string  `json:"time"`
+}
+
+// unwrapContainerLine returns the inner line of a Docker json-file or CRI line and
+// whether the line is continued in the next line of the same stream
+func unwrapContainerLine(line []byte) (record containerRecord, partial bool, ok bool) {
+	if bytes.HasPrefix(line, []byte(`{"log":`)) {
+		var d dockerLine
+		if json.Unmarshal(line, &d) != nil || d.Log == nil || d.Stream == "" {
+			return containerRecord{}, false, false
+		}
+
+		// docker splits lines longer than 16k, only the last part ends with a newline
+		inner := []byte(*d.Log)
+		partial = !bytes.HasSuffix(inner, []byte("\n"))
+
+		return containerRecord{line: bytes.TrimRight(inner, "\r\n"), stream: d.Stream, time: parseTime(d.Time)}, partial, true
+	}
+
+	match := criLinePattern.FindSubmatch(line)
+	if match == nil {
+		return containerRecord{}, false, false
+	}
+
+	t := parseTime(string(match[1]))
+	if t.IsZero() {
+		return containerRecord{}, false, false
+	}
+
+	return containerRecord{line: match[4], stream: string(match[2]), time: t}, string(match[3]) == criPartial, true
+}
+
+// containerReader reads lines and unwraps the envelopes of container runtimes,
+// partial lines are reassembled per stream
+type containerReader struct {
+	lines *lineReader
+	// partial are the pending partial lines by stream
+	partial map[string]*partialLine
+	// ready are the complete records in the order they have to be returned
+	ready  []containerRecord
+	record containerRecord
+}
+
+// partialLine is a line which is reassembled from several partial lines
+type partialLine struct {
+	record    containerRecord
+	truncated int
+}
+
+func newContainerReader(lines *lineReader) *containerReader {
+	return &containerReader{lines: lines, partial: map[string]*partialLine{}}
+}
+
+// Scan reads the next complete record and reports whether a record was read
+func (r *containerReader) Scan() bool {
+	for len(r.ready) == 0 {
+		if !r.lines.Scan() {
+			r.flush()
+			break
+		}
+
+		r.read(r.lines.Bytes())
+	}
+
+	if len(r.ready) == 0 {
+		return false
+	}
+
+	r.record, r.ready = r.ready[0], r.ready[1:]
+
+	return true
+}
+
+func (r *containerReader) read(line []byte) {
+	record, partial, ok := unwrapContainerLine(line)
+	if !ok {
+		r.ready = append(r.ready, containerRecord{line: append([]byte(nil), line...)})
+		return
+	}
+
+	pending := r.partial[record.stream]
+	if pending == nil {
+		pending = &partialLine{record: containerRecord{stream: record.stream, time: record.time}}
+	}
+
+	// the reassembled line is truncated like a single line
+	keep := len(record.line)
+	if max := r.lines.max; max > 0 && len(pending.record.line)+keep > max {
+		keep = max - len(pending.record.line)
+	}
+
+	pending.record.line = append(pending.record.line, record.line[:keep]...)
+	pending.truncated += len(record.line) - keep
+
+	if partial {
+		r.partial[record.stream] = pending
+		return
+	}
+
+	delete(r.partial, record.stream)
+	r.ready = append(r.ready, pending.complete())
+}
+
+// flush returns the partial lines which were not completed at the end of the input
+func (r *containerReader) flush() {
+	for _, stream := range []string{"stdout", "stderr"} {
+		if pending := r.partial[stream]; pending != nil {
+			r.ready = append(r.ready, pending.complete())
+			delete(r.partial, stream)
+		}
+	}
+}
+
+// complete returns the record with a marker appended if the line was truncated
+func (p *partialLine) complete() containerRecord {
+	if p.truncated > 0 {
+		p.record.line = append(p.record.line, fmt.Sprintf(" … [truncated %d bytes]", p.truncated)...)
+	}
+
+	return p.record
+}
+
+// Record returns the current record
+func (r *containerReader) Record() containerRecord {
+	return r.record
+}
+
+// Err returns the first error except io.EOF
+func (r *containerReader) Err() error {
+	return r.lines.Err()
+}
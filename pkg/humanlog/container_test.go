@@ -0,0 +1,128 @@
+package humanlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unwrapContainerLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantRecord  containerRecord
+		wantPartial bool
+		wantOk      bool
+	}{
+		{
+			name:       "docker",
+			line:       `{"log":"{\"msg\":\"hello\"}\n","stream":"stdout","time":"2020-07-14T09:38:15.1Z"}`,
+			wantRecord: containerRecord{line: []byte(`{"msg":"hello"}`), stream: "stdout", time: time.Date(2020, 7, 14, 9, 38, 15, 100000000, time.UTC)},
+			wantOk:     true,
+		},
+		{
+			name:        "docker partial",
+			line:        `{"log":"first part","stream":"stderr","time":"2020-07-14T09:38:15.1Z"}`,
+			wantRecord:  containerRecord{line: []byte("first part"), stream: "stderr", time: time.Date(2020, 7, 14, 9, 38, 15, 100000000, time.UTC)},
+			wantPartial: true,
+			wantOk:      true,
+		},
+		{
+			name:       "cri",
+			line:       `2020-07-14T09:38:14.500000000Z stdout F {"msg":"hello"}`,
+			wantRecord: containerRecord{line: []byte(`{"msg":"hello"}`), stream: "stdout", time: time.Date(2020, 7, 14, 9, 38, 14, 500000000, time.UTC)},
+			wantOk:     true,
+		},
+		{
+			name:        "cri partial",
+			line:        `2020-07-14T09:38:14.500000000Z stderr P first part`,
+			wantRecord:  containerRecord{line: []byte("first part"), stream: "stderr", time: time.Date(2020, 7, 14, 9, 38, 14, 500000000, time.UTC)},
+			wantPartial: true,
+			wantOk:      true,
+		},
+		{
+			name:   "json log line",
+			line:   `{"log":"not a docker line"}`,
+			wantOk: false,
+		},
+		{
+			name:   "plain text",
+			line:   `now stdout F is no timestamp`,
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRecord, gotPartial, gotOk := unwrapContainerLine([]byte(tt.line))
+			assert.Equal(t, tt.wantOk, gotOk)
+			assert.Equal(t, tt.wantPartial, gotPartial)
+
+			if tt.wantOk {
+				assert.Equal(t, string(tt.wantRecord.line), string(gotRecord.line))
+				assert.Equal(t, tt.wantRecord.stream, gotRecord.stream)
+				assert.True(t, tt.wantRecord.time.Equal(gotRecord.time))
+			}
+		})
+	}
+}
+
+func Test_containerReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		max         int
+		want        []string
+		wantStreams []string
+	}{
+		{
+			name: "cri partial lines of both streams",
+			input: "2020-07-14T09:38:14Z stdout P first \n" +
+				"2020-07-14T09:38:14Z stderr F error\n" +
+				"2020-07-14T09:38:14Z stdout P second \n" +
+				"2020-07-14T09:38:14Z stdout F third\n",
+			want:        []string{"error", "first second third"},
+			wantStreams: []string{"stderr", "stdout"},
+		},
+		{
+			name: "docker partial lines and plain text",
+			input: `{"log":"first ","stream":"stdout","time":"2020-07-14T09:38:14Z"}` + "\n" +
+				"plain text\n" +
+				`{"log":"second\n","stream":"stdout","time":"2020-07-14T09:38:14Z"}` + "\n",
+			want:        []string{"plain text", "first second"},
+			wantStreams: []string{"", "stdout"},
+		},
+		{
+			name:        "incomplete partial line at the end",
+			input:       "2020-07-14T09:38:14Z stdout P never completed\n",
+			want:        []string{"never completed"},
+			wantStreams: []string{"stdout"},
+		},
+		{
+			name: "truncated",
+			input: strings.Repeat("2020-07-14T09:38:14Z stdout P 0123456789\n", 4) +
+				"2020-07-14T09:38:14Z stdout F 0123456789\n",
+			max:         40,
+			want:        []string{strings.Repeat("0123456789", 4) + " … [truncated 10 bytes]"},
+			wantStreams: []string{"stdout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newContainerReader(newLineReader(strings.NewReader(tt.input), tt.max))
+
+			var got, gotStreams []string
+			for r.Scan() {
+				got = append(got, string(r.Record().line))
+				gotStreams = append(gotStreams, r.Record().stream)
+			}
+
+			assert.NoError(t, r.Err())
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantStreams, gotStreams)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package humanlog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Converter reads json log lines, parses and renders them
+type Converter struct {
+	Parser   Parser
+	Renderer Renderer
+	// Fields selects the additional fields which are rendered
+	Fields FieldSelector
+	// Levels drops entries by their level
+	Levels LevelFilter
+	// MaxLineSize truncates longer lines with a marker instead of parsing them, 0 means no limit
+	MaxLineSize int
+}
+
+// NewConverter returns a Converter detecting the format of every line
+// and rendering it as plain text
+func NewConverter() *Converter {
+	return &Converter{
+		Parser:   AutoParser{},
+		Renderer: TextRenderer{},
+	}
+}
+
+// Convert reads r line by line and writes the human readable output to w.
+// Lines which could not be parsed are written unchanged.
+// Docker json-file and CRI container log lines are unwrapped and partial lines are reassembled.
+func (c *Converter) Convert(r io.Reader, w io.Writer) error {
+	records := newContainerReader(newLineReader(r, c.MaxLineSize))
+
+	for records.Scan() {
+		err := c.convertRecord(records.Record(), w)
+		if err != nil {
+			return err
+		}
+	}
+
+	return records.Err()
+}
+
+// ConvertLine parses and renders all entries of a single line. The output of the line is written
+// with a single Write call, so concurrent converters could share a synchronized writer.
+func (c *Converter) ConvertLine(line []byte, w io.Writer) error {
+	record, _, ok := unwrapContainerLine(line)
+	if !ok {
+		record = containerRecord{line: line}
+	}
+
+	return c.convertRecord(record, w)
+}
+
+func (c *Converter) convertRecord(record containerRecord, w io.Writer) error {
+	var buf bytes.Buffer
+
+	for _, entry := range parseRecord(c.Parser, record) {
+		err := c.render(&buf, entry, record.line)
+		if err != nil {
+			return err
+		}
+	}
+
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// parseRecord returns all entries of the record with the stream and time of the container envelope,
+// a single nil entry is returned if the line could not be parsed or contains no log records
+func parseRecord(p Parser, record containerRecord) []*Entry {
+	entries, err := ParseAll(p, record.line)
+	if err != nil || len(entries) == 0 {
+		return []*Entry{nil}
+	}
+
+	for _, e := range entries {
+		e.Stream = record.stream
+
+		if e.Time.IsZero() && !record.time.IsZero() {
+			e.Time = record.time
+		}
+	}
+
+	return entries
+}
+
+// render writes the filtered entry or the unchanged line if it could not be parsed
+func (c *Converter) render(w io.Writer, entry *Entry, line []byte) error {
+	if entry == nil {
+		_, err := fmt.Fprintln(w, string(line))
+		return err
+	}
+
+	if !c.Levels.Match(entry) {
+		return nil
+	}
+
+	entry.Fields = c.Fields.selectEntry(entry)
+
+	return c.Renderer.Render(w, entry)
+}
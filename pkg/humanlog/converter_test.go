@@ -0,0 +1,89 @@
+package humanlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConverter_Convert(t *testing.T) {
+	huge := `{"level":"ERROR","timestamp":"2020-07-14T09:38:14.977Z","message":"` + strings.Repeat("x", 2*1024*1024) + `"}`
+
+	tests := []struct {
+		name        string
+		input       string
+		maxLineSize int
+		wantW       string
+		wantErr     bool
+	}{
+		{
+			name:  "json and plain text",
+			input: "{\"level\":\"INFO\",\"timestamp\":\"2020-07-14T09:38:14.977Z\",\"message\":\"sample output\",\"loggerName\":\"org.acme.MyClass\"}\nplain text\n",
+			wantW: "INFO 2020-07-14T09:38:14.977Z\torg.acme.MyClass\tsample output\nplain text\n",
+		},
+		{
+			name:  "dotnet scopes by default",
+			input: `{"Timestamp":"2021-03-19T13:01:52.734Z","EventId":0,"LogLevel":"Information","Category":"Svc","Message":"msg","State":{"Message":"msg"},"Scopes":["s1",{"Message":"Order 7","OrderId":7}]}` + "\n",
+			wantW: "INFO 2021-03-19T13:01:52.734Z\tSvc\tmsg\tScopes=\"s1 => Order 7 OrderId=7\"\n",
+		},
+		{
+			name:  "unknown json object",
+			input: "{\"foo\":\"bar\"}\n",
+			wantW: "{\"foo\":\"bar\"}\n",
+		},
+		{
+			name:  "line without log records",
+			input: `{"resourceLogs":[{"scopeLogs":[{"scope":{"name":"app"}}]}]}` + "\n",
+			wantW: `{"resourceLogs":[{"scopeLogs":[{"scope":{"name":"app"}}]}]}` + "\n",
+		},
+		{
+			name:  "line larger than 1 MiB",
+			input: huge + "\nplain text\n",
+			wantW: "ERROR 2020-07-14T09:38:14.977Z\t\t" + strings.Repeat("x", 2*1024*1024) + "\nplain text\n",
+		},
+		{
+			name:        "truncated line",
+			input:       huge + "\nplain text\n",
+			maxLineSize: 20,
+			wantW:       "{\"level\":\"ERROR\",\"ti … [truncated 2097201 bytes]\nplain text\n",
+		},
+		{
+			name: "several entries in a line",
+			input: `{"resourceLogs":[{"scopeLogs":[{"scope":{"name":"app"},"logRecords":[` +
+				`{"timeUnixNano":"1544712660300000000","severityNumber":9,"body":{"stringValue":"first"}},` +
+				`{"timeUnixNano":"1544712660400000000","severityNumber":13,"body":{"stringValue":"second"}}]}]}]}` + "\n",
+			wantW: "INFO 2018-12-13T14:51:00.300Z\tapp\tfirst\nWARN 2018-12-13T14:51:00.400Z\tapp\tsecond\n",
+		},
+		{
+			name: "container envelopes",
+			input: `{"log":"{\"level\":\"info\",\"ts\":1594719495,\"logger\":\"z\",\"msg\":\"in docker\"}\n","stream":"stdout","time":"2020-07-14T09:38:15.1Z"}` + "\n" +
+				"2020-07-14T09:38:16.5Z stderr P {\"level\":\"ERROR\",\"message\":\"without \n" +
+				"2020-07-14T09:38:16.5Z stderr F timestamp\",\"loggerName\":\"q\"}\n" +
+				"2020-07-14T09:38:17Z stderr F plain text\n",
+			wantW: "INFO 2020-07-14T09:38:15.000Z\tz\tin docker\n" +
+				"ERROR 2020-07-14T09:38:16.500Z\tstream=stderr q\twithout timestamp\n" +
+				"plain text\n",
+		},
+		{
+			name:  "empty",
+			input: "",
+			wantW: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			c := NewConverter()
+			c.MaxLineSize = tt.maxLineSize
+			err := c.Convert(strings.NewReader(tt.input), w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("Convert() gotW = \n%v, want \n%v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright © 2020 Florian Hopfensperger <f.hopfensperger@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package humanlog parses one line json log messages of various logging
+// frameworks and renders them in a human readable form.
+//
+// A Parser turns a single line into an Entry and a Renderer writes the Entry,
+// the Converter combines both to process a whole stream:
+//
+//	c := humanlog.NewConverter()
+//	err := c.Convert(os.Stdin, os.Stdout)
+package humanlog
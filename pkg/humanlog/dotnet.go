@@ -0,0 +1,141 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const (
+	// dotNetInnerException starts the header of an inner exception
+	dotNetInnerException = "--->"
+	// dotNetEndOfInnerException separates the frames of an inner exception from the outer ones
+	dotNetEndOfInnerException = "--- End of inner exception stack trace ---"
+	// dotNetScopeSeparator joins the scopes like the .NET simple console
+	dotNetScopeSeparator = " => "
+)
+
+// dotNetScopes returns the tracing context and the scope messages of the .NET json console formatter,
+// which adds the Activity ids as scope. The properties of other object scopes follow their message as key=value.
+func dotNetScopes(scopes []json.RawMessage) (Tracing, Fields) {
+	var trace Tracing
+
+	messages := make([]string, 0, len(scopes))
+
+	for _, raw := range scopes {
+		var message string
+		if json.Unmarshal(raw, &message) == nil {
+			messages = append(messages, message)
+			continue
+		}
+
+		properties, err := decodeFields(raw)
+		if err != nil {
+			messages = append(messages, string(raw))
+			continue
+		}
+
+		message, _ = properties["Message"].(string)
+		delete(properties, "Message")
+		delete(properties, "{OriginalFormat}")
+
+		// the properties of the Activity scope are its tracing context
+		if traceID, ok := properties["TraceId"].(string); ok && traceID != "" {
+			trace.TraceID = traceID
+			trace.SpanID, _ = properties["SpanId"].(string)
+			properties = nil
+		}
+
+		parts := make([]string, 0, 2)
+		if message != "" {
+			parts = append(parts, message)
+		}
+
+		if len(properties) > 0 {
+			parts = append(parts, properties.String())
+		}
+
+		if len(parts) > 0 {
+			messages = append(messages, strings.Join(parts, " "))
+		}
+	}
+
+	if len(messages) == 0 {
+		return trace, nil
+	}
+
+	return trace, Fields{"Scopes": strings.Join(messages, dotNetScopeSeparator)}
+}
+
+// parseDotNetException parses the output of Exception.ToString() into an error tree.
+// The headers of all inner exceptions follow the outer one, the frames start with
+// the innermost exception and are separated by an end of inner exception line.
+func parseDotNetException(s string) *Error {
+	var errs []*Error
+
+	current := -1
+
+	for _, line := range strings.Split(strings.TrimRight(s, "\r\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			continue
+		case len(errs) == 0, strings.HasPrefix(line, dotNetInnerException):
+			errs = append(errs, parseDotNetExceptionHeader(strings.TrimSpace(strings.TrimPrefix(line, dotNetInnerException))))
+			current = len(errs) - 1
+		case line == dotNetEndOfInnerException:
+			if current > 0 {
+				current--
+			}
+		case strings.HasPrefix(line, "at "):
+			errs[current].Frames = append(errs[current].Frames, parseDotNetFrame(strings.TrimPrefix(line, "at ")))
+		case strings.HasPrefix(line, "---"):
+			// e.g. end of stack trace from previous location of async methods
+			continue
+		case len(errs[current].Frames) == 0:
+			// multi line message
+			errs[current].Message += "\n" + line
+		}
+	}
+
+	for i := len(errs) - 1; i > 0; i-- {
+		errs[i-1].Cause = errs[i]
+	}
+
+	return errs[0]
+}
+
+// parseDotNetExceptionHeader splits "System.ArgumentException: message" into type and message
+func parseDotNetExceptionHeader(header string) *Error {
+	i := strings.Index(header, ": ")
+	if i < 0 {
+		return &Error{Type: header}
+	}
+
+	return &Error{Type: header[:i], Message: header[i+2:]}
+}
+
+// parseDotNetFrame parses a frame like "App.Service.Run(String name) in /src/Service.cs:line 42",
+// the location is only available with debug symbols
+func parseDotNetFrame(frame string) Frame {
+	method, location := frame, ""
+	if i := strings.LastIndex(frame, " in "); i >= 0 {
+		method, location = frame[:i], frame[i+len(" in "):]
+	}
+
+	if i := strings.IndexByte(method, '('); i > 0 {
+		method = method[:i]
+	}
+
+	f := Frame{Method: method}
+
+	if i := strings.LastIndex(location, ":line "); i >= 0 {
+		f.Class = location[:i]
+		f.Line, _ = strconv.Atoi(location[i+len(":line "):])
+	} else {
+		f.Class = location
+	}
+
+	return f
+}
@@ -0,0 +1,114 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseDotNetException(t *testing.T) {
+	tests := []struct {
+		name      string
+		exception string
+		want      *Error
+	}{
+		{
+			name:      "without frames",
+			exception: "System.Exception: Something failed",
+			want:      &Error{Type: "System.Exception", Message: "Something failed"},
+		},
+		{
+			name: "inner exceptions",
+			exception: "System.InvalidOperationException: Job failed\r\n" +
+				" ---> System.AggregateException: One or more errors occurred.\r\n" +
+				" ---> System.ArgumentException: Value is invalid (Parameter 'name')\r\n" +
+				"   at App.Service.Validate(String name) in /src/Service.cs:line 42\r\n" +
+				"   at App.Service.Run()\r\n" +
+				"   --- End of inner exception stack trace ---\r\n" +
+				"   at App.Service.RunAll() in /src/Service.cs:line 12\r\n" +
+				"--- End of stack trace from previous location ---\r\n" +
+				"   at App.Service.RunAllAsync() in /src/Service.cs:line 10\r\n" +
+				"   --- End of inner exception stack trace ---\r\n" +
+				"   at App.Worker.Execute() in /src/Worker.cs:line 17\r\n",
+			want: &Error{
+				Type:    "System.InvalidOperationException",
+				Message: "Job failed",
+				Frames:  []Frame{{Class: "/src/Worker.cs", Method: "App.Worker.Execute", Line: 17}},
+				Cause: &Error{
+					Type:    "System.AggregateException",
+					Message: "One or more errors occurred.",
+					Frames: []Frame{
+						{Class: "/src/Service.cs", Method: "App.Service.RunAll", Line: 12},
+						{Class: "/src/Service.cs", Method: "App.Service.RunAllAsync", Line: 10},
+					},
+					Cause: &Error{
+						Type:    "System.ArgumentException",
+						Message: "Value is invalid (Parameter 'name')",
+						Frames: []Frame{
+							{Class: "/src/Service.cs", Method: "App.Service.Validate", Line: 42},
+							{Method: "App.Service.Run"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "multi line message",
+			exception: "System.Exception: first line\nsecond line\n   at App.Program.Main()",
+			want: &Error{
+				Type:    "System.Exception",
+				Message: "first line\nsecond line",
+				Frames:  []Frame{{Method: "App.Program.Main"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseDotNetException(tt.exception))
+		})
+	}
+}
+
+func Test_dotNetScopes(t *testing.T) {
+	tests := []struct {
+		name       string
+		scopes     string
+		wantTrace  Tracing
+		wantFields Fields
+	}{
+		{
+			name:       "activity and string scope",
+			scopes:     `[{"Message":"SpanId:abc, TraceId:def, ParentId:000","SpanId":"abc","TraceId":"def","ParentId":"000"},"Job 7"]`,
+			wantTrace:  Tracing{TraceID: "def", SpanID: "abc"},
+			wantFields: Fields{"Scopes": "SpanId:abc, TraceId:def, ParentId:000 => Job 7"},
+		},
+		{
+			name:       "object without message",
+			scopes:     `[{"ConnectionId":"42"}]`,
+			wantFields: Fields{"Scopes": "ConnectionId=42"},
+		},
+		{
+			name:       "object with message and properties",
+			scopes:     `[{"Message":"Order 7 of tenant a","OrderId":7,"Tenant":"a","{OriginalFormat}":"Order {OrderId} of tenant {Tenant}"},"Job 7"]`,
+			wantFields: Fields{"Scopes": "Order 7 of tenant a OrderId=7 Tenant=a => Job 7"},
+		},
+		{
+			name:       "empty object",
+			scopes:     `[{}]`,
+			wantFields: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var scopes []json.RawMessage
+			assert.NoError(t, json.Unmarshal([]byte(tt.scopes), &scopes))
+
+			gotTrace, gotFields := dotNetScopes(scopes)
+			assert.Equal(t, tt.wantTrace, gotTrace)
+			assert.Equal(t, tt.wantFields, gotFields)
+		})
+	}
+}
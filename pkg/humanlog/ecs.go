@@ -0,0 +1,88 @@
+package humanlog
+
+import "encoding/json"
+
+// EcsLogMessage Elastic Common Schema log message type, e.g. written by logstash-logback,
+// Elastic.CommonSchema or the EcsLayout.json template of the log4j2 JsonTemplateLayout.
+// The fields could be dotted keys like "log.level" or nested objects like {"log":{"level":...}}.
+type EcsLogMessage struct {
+	Timestamp       string `json:"@timestamp"`
+	Level           string `json:"log.level"`
+	Logger          string `json:"log.logger,omitempty"`
+	Message         string `json:"message"`
+	ServiceName     string `json:"service.name,omitempty"`
+	TraceID         string `json:"trace.id,omitempty"`
+	SpanID          string `json:"span.id,omitempty"`
+	ErrorType       string `json:"error.type,omitempty"`
+	ErrorMessage    string `json:"error.message,omitempty"`
+	ErrorStackTrace string `json:"error.stack_trace,omitempty"`
+}
+
+// UnmarshalJSON decodes dotted keys and nested objects alike
+func (elm *EcsLogMessage) UnmarshalJSON(data []byte) error {
+	fields, err := decodeFields(data)
+	if err != nil {
+		return err
+	}
+
+	field := func(key string) string {
+		s, _ := fields[key].(string)
+		return s
+	}
+
+	*elm = EcsLogMessage{
+		Timestamp:       field("@timestamp"),
+		Level:           field("log.level"),
+		Logger:          field("log.logger"),
+		Message:         field("message"),
+		ServiceName:     field("service.name"),
+		TraceID:         field("trace.id"),
+		SpanID:          field("span.id"),
+		ErrorType:       field("error.type"),
+		ErrorMessage:    field("error.message"),
+		ErrorStackTrace: field("error.stack_trace"),
+	}
+
+	return nil
+}
+
+func (elm *EcsLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Time:     parseTime(elm.Timestamp),
+		RawTime:  elm.Timestamp,
+		Level:    ParseLevel(elm.Level),
+		RawLevel: elm.Level,
+		Logger:   elm.Logger,
+		Message:  elm.Message,
+		Service:  elm.ServiceName,
+		Trace:    Tracing{TraceID: elm.TraceID, SpanID: elm.SpanID},
+	}
+
+	// log message contains an error, the stack trace already starts with type and message
+	switch {
+	case elm.ErrorStackTrace != "":
+		e.Error = &Error{Stacktrace: elm.ErrorStackTrace}
+	case elm.ErrorType != "" || elm.ErrorMessage != "":
+		e.Error = &Error{Type: elm.ErrorType, Message: elm.ErrorMessage}
+	}
+
+	return e
+}
+
+// isEcs reports whether the keys belong to an ECS log message, the level is either
+// a dotted key or nested in the log object
+func isEcs(keys map[string]json.RawMessage) bool {
+	if !hasKeys(keys, "@timestamp") {
+		return false
+	}
+
+	if hasKeys(keys, "log.level") {
+		return true
+	}
+
+	var log struct {
+		Level *string `json:"level"`
+	}
+
+	return hasKeys(keys, "log") && json.Unmarshal(keys["log"], &log) == nil && log.Level != nil
+}
@@ -0,0 +1,94 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcsLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Entry
+	}{
+		{
+			name: "dotted keys with stack trace",
+			line: `{"@timestamp":"2020-05-25T14:38:17.123Z","log.level":"ERROR","log.logger":"logtest.Main","message":"Boom","service.name":"orders","trace.id":"4bf92f","span.id":"00f067",` +
+				`"error.type":"java.lang.RuntimeException","error.message":"outer","error.stack_trace":"java.lang.RuntimeException: outer\n\tat logtest.Main.main(Main.java:29)"}`,
+			want: &Entry{
+				Time:     time.Date(2020, 5, 25, 14, 38, 17, 123000000, time.UTC),
+				RawTime:  "2020-05-25T14:38:17.123Z",
+				Level:    LevelError,
+				RawLevel: "ERROR",
+				Logger:   "logtest.Main",
+				Message:  "Boom",
+				Service:  "orders",
+				Trace:    Tracing{TraceID: "4bf92f", SpanID: "00f067"},
+				Error:    &Error{Stacktrace: "java.lang.RuntimeException: outer\n\tat logtest.Main.main(Main.java:29)"},
+			},
+		},
+		{
+			name: "nested objects without stack trace",
+			line: `{"@timestamp":"2020-05-25T14:38:17.123Z","log":{"level":"Warning","logger":"Checkout.Api"},"message":"Retry","service":{"name":"checkout"},"trace":{"id":"4bf92f"},` +
+				`"error":{"type":"System.IO.IOException","message":"inner"}}`,
+			want: &Entry{
+				Time:     time.Date(2020, 5, 25, 14, 38, 17, 123000000, time.UTC),
+				RawTime:  "2020-05-25T14:38:17.123Z",
+				Level:    LevelWarn,
+				RawLevel: "Warning",
+				Logger:   "Checkout.Api",
+				Message:  "Retry",
+				Service:  "checkout",
+				Trace:    Tracing{TraceID: "4bf92f"},
+				Error:    &Error{Type: "System.IO.IOException", Message: "inner"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elm := &EcsLogMessage{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.line), elm))
+			assert.Equal(t, tt.want, elm.toEntry())
+		})
+	}
+}
+
+func TestEcs_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantFormat string
+		wantFields Fields
+	}{
+		{
+			name:       "dotted keys",
+			line:       `{"@timestamp":"2020-05-25T14:38:17.123Z","ecs.version":"1.2.0","log.level":"INFO","message":"Hello","process.thread.name":"main","log.logger":"logtest.Main"}`,
+			wantFormat: "ecs",
+			wantFields: Fields{"ecs.version": "1.2.0", "process.thread.name": "main"},
+		},
+		{
+			name:       "nested objects",
+			line:       `{"@timestamp":"2020-05-25T14:38:17.123Z","ecs":{"version":"8.6.0"},"log":{"level":"Information","logger":"Checkout.Api"},"message":"Hello","service":{"name":"checkout","version":"1.0"}}`,
+			wantFormat: "ecs",
+			wantFields: Fields{"ecs.version": "8.6.0", "service.version": "1.0"},
+		},
+		{
+			name:       "spring boot",
+			line:       `{"@timestamp":"2020-05-25T14:38:17.123Z","level":"INFO","message":"Hello","logger_name":"logtest.Main"}`,
+			wantFormat: "springboot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AutoParser{}.Parse([]byte(tt.line))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, got.Format)
+			assert.Equal(t, tt.wantFields, got.Fields)
+		})
+	}
+}
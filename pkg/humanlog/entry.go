@@ -0,0 +1,93 @@
+package humanlog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeFormat is used to render the timestamp of an entry
+const DefaultTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// Entry is a single log line normalized across all formats
+type Entry struct {
+	// Format is the name of the format the line was parsed with
+	Format string
+	// Time of the log event, zero if the timestamp could not be parsed
+	Time time.Time
+	// RawTime is the timestamp as found in the log line
+	RawTime string
+	// Level is the canonical severity
+	Level Level
+	// RawLevel is the level as found in the log line
+	RawLevel string
+	Logger   string
+	Message  string
+	// Service is the name of the service which wrote the log event, if known
+	Service string
+	// Stream is stdout or stderr if the line was unwrapped from a container log
+	Stream string
+	// Error is the error or exception attached to the log event
+	Error *Error
+	// Trace is the tracing context of the log event
+	Trace Tracing
+	// Fields contains all additional key/value pairs, nested objects are flattened with dot paths
+	Fields Fields
+}
+
+// Error is an error or exception, optionally caused by another one
+type Error struct {
+	Type    string
+	Message string
+	// Frames of a structured stack trace
+	Frames []Frame
+	// Stacktrace is an unstructured, already formatted stack trace
+	Stacktrace string
+	Cause      *Error
+}
+
+// Fields are additional key/value pairs of a log event
+type Fields map[string]interface{}
+
+// String returns the fields as key=value pairs sorted by key
+func (f Fields) String() string {
+	keys := make([]string, 0, len(f))
+	for key := range f {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := fmt.Sprint(f[key])
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+
+		pairs = append(pairs, key+"="+value)
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// Timestamp returns the formatted time of the entry or the raw timestamp
+// if it could not be parsed
+func (e *Entry) Timestamp() string {
+	if e.Time.IsZero() {
+		return e.RawTime
+	}
+
+	return e.Time.Format(DefaultTimeFormat)
+}
+
+// LevelName returns the canonical level name or the raw level if it is unknown
+func (e *Entry) LevelName() string {
+	if e.Level == LevelUnknown {
+		return e.RawLevel
+	}
+
+	return e.Level.String()
+}
@@ -0,0 +1,63 @@
+package humanlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields Fields
+		want   string
+	}{
+		{
+			name:   "sorted by key",
+			fields: Fields{"request": "default/queue", "controller": "scaledobject", "attempt": 3},
+			want:   "attempt=3 controller=scaledobject request=default/queue",
+		},
+		{
+			name:   "quoted values",
+			fields: Fields{"message": "hello world", "empty": ""},
+			want:   `empty="" message="hello world"`,
+		},
+		{
+			name:   "empty",
+			fields: Fields{},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fields.String())
+		})
+	}
+}
+
+func TestEntry_Timestamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		want  string
+	}{
+		{
+			name:  "parsed time",
+			entry: Entry{Time: time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC), RawTime: "2020-07-14T09:38:14.977Z"},
+			want:  "2020-07-14T09:38:14.977Z",
+		},
+		{
+			name:  "raw time",
+			entry: Entry{RawTime: "yesterday"},
+			want:  "yesterday",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.Timestamp())
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package humanlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// FieldSelector decides which additional fields are passed to the renderer
+type FieldSelector struct {
+	// Show enables rendering of additional fields
+	Show bool
+	// Include only keeps the given keys, nested keys are matched by their dot path prefix
+	Include []string
+	// Exclude removes the given keys, nested keys are matched by their dot path prefix
+	Exclude []string
+}
+
+// Select returns the fields which should be rendered
+func (s FieldSelector) Select(fields Fields) Fields {
+	if !s.Show && len(s.Include) == 0 {
+		return nil
+	}
+
+	selected := Fields{}
+
+	for key, value := range fields {
+		if len(s.Include) > 0 && !matchesKey(key, s.Include) {
+			continue
+		}
+
+		if matchesKey(key, s.Exclude) {
+			continue
+		}
+
+		selected[key] = value
+	}
+
+	return selected
+}
+
+// selectEntry returns the fields of the entry which should be rendered, the default fields
+// of its format are kept if additional fields are not requested
+func (s FieldSelector) selectEntry(e *Entry) Fields {
+	if s.Show || len(s.Include) > 0 {
+		return s.Select(e.Fields)
+	}
+
+	f, err := FormatByName(e.Format)
+	if err != nil || len(f.defaultFields) == 0 {
+		return nil
+	}
+
+	return FieldSelector{Include: f.defaultFields, Exclude: s.Exclude}.Select(e.Fields)
+}
+
+// matchesKey reports whether key equals one of the names or is nested below it
+func matchesKey(key string, names []string) bool {
+	for _, name := range names {
+		if key == name || strings.HasPrefix(key, name+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extraFields returns all keys of the json line which are not decoded by the log message,
+// nested objects are flattened with dot paths
+func extraFields(line []byte, logMessage CommonLogMessage) (Fields, error) {
+	var values map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := Fields{}
+	flatten(fields, "", values)
+
+	// decoded keys are removed by their dot path, so dotted keys like "log.level" are also
+	// removed from nested objects and unknown keys of nested structs are kept
+	keys := jsonKeys(logMessage)
+	for key := range fields {
+		if matchesKey(key, keys) || isParentKey(key, keys) {
+			delete(fields, key)
+		}
+	}
+
+	return fields, nil
+}
+
+// isParentKey reports whether one of the names is nested below key, e.g. a null value of a nested struct
+func isParentKey(key string, names []string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(name, key+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeFields decodes a json object into flattened fields
+func decodeFields(raw json.RawMessage) (Fields, error) {
+	var values map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := Fields{}
+	flatten(fields, "", values)
+
+	return fields, nil
+}
+
+func flatten(fields Fields, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(fields, key, v)
+		case []interface{}:
+			b, _ := json.Marshal(v)
+			fields[key] = string(b)
+		default:
+			fields[key] = v
+		}
+	}
+}
+
+// jsonKeys returns the json paths of all fields decoded into the struct v points to,
+// nested structs only claim the keys of their own fields, e.g. "mdc.traceId"
+func jsonKeys(v interface{}) []string {
+	return structKeys(reflect.TypeOf(v), "", map[reflect.Type]bool{})
+}
+
+var jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func structKeys(t reflect.Type, prefix string, seen map[reflect.Type]bool) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// recursive types like a cause of an exception claim everything below them
+	seen[t] = true
+	defer delete(seen, t)
+
+	var keys []string
+
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		field := t.Field(i).Type
+		for field.Kind() == reflect.Ptr {
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Struct && !seen[field] && !reflect.PtrTo(field).Implements(jsonUnmarshaler) {
+			keys = append(keys, structKeys(field, name, seen)...)
+			continue
+		}
+
+		keys = append(keys, name)
+	}
+
+	return keys
+}
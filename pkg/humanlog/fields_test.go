@@ -0,0 +1,171 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldSelector_Select(t *testing.T) {
+	fields := Fields{
+		"threadName":             "pool-1-thread-1",
+		"hostName":               "myhost",
+		"State.Message":          "Role authorization requirement satisfied",
+		"State.{OriginalFormat}": "Role authorization requirement satisfied",
+	}
+
+	tests := []struct {
+		name     string
+		selector FieldSelector
+		want     Fields
+	}{
+		{
+			name:     "hidden by default",
+			selector: FieldSelector{},
+			want:     nil,
+		},
+		{
+			name:     "show all",
+			selector: FieldSelector{Show: true},
+			want:     fields,
+		},
+		{
+			name:     "include nested by prefix",
+			selector: FieldSelector{Include: []string{"State", "hostName"}},
+			want: Fields{
+				"hostName":               "myhost",
+				"State.Message":          "Role authorization requirement satisfied",
+				"State.{OriginalFormat}": "Role authorization requirement satisfied",
+			},
+		},
+		{
+			name:     "exclude",
+			selector: FieldSelector{Show: true, Exclude: []string{"State.{OriginalFormat}", "threadName"}},
+			want: Fields{
+				"hostName":      "myhost",
+				"State.Message": "Role authorization requirement satisfied",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.selector.Select(fields))
+		})
+	}
+}
+
+func TestFieldSelector_selectEntry(t *testing.T) {
+	zap := func() *Entry {
+		return &Entry{Format: "zap", Fields: Fields{"controller": "scaledobject", "request": "default/queue", "worker": json.Number("1")}}
+	}
+
+	tests := []struct {
+		name     string
+		selector FieldSelector
+		entry    *Entry
+		want     Fields
+	}{
+		{
+			name:     "default fields of the format",
+			selector: FieldSelector{},
+			entry:    zap(),
+			want:     Fields{"controller": "scaledobject", "request": "default/queue"},
+		},
+		{
+			name:     "excluded default field",
+			selector: FieldSelector{Exclude: []string{"request"}},
+			entry:    zap(),
+			want:     Fields{"controller": "scaledobject"},
+		},
+		{
+			name:     "show all",
+			selector: FieldSelector{Show: true},
+			entry:    zap(),
+			want:     Fields{"controller": "scaledobject", "request": "default/queue", "worker": json.Number("1")},
+		},
+		{
+			name:     "format without default fields",
+			selector: FieldSelector{},
+			entry:    &Entry{Format: "springboot", Fields: Fields{"thread_name": "main"}},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.selector.selectEntry(tt.entry))
+		})
+	}
+}
+
+func Test_extraFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		logMessage CommonLogMessage
+		want       Fields
+		wantErr    bool
+	}{
+		{
+			name:       "spring boot",
+			line:       `{"@timestamp":"2020-07-15T19:09:39.983Z","@version":"1","message":"My log message","logger_name":"org.acme.MyClass","thread_name":"pool-1-thread-1","level":"INFO","level_value":20000}`,
+			logMessage: &SpringBootLogMessage{},
+			want:       Fields{"@version": "1", "thread_name": "pool-1-thread-1"},
+		},
+		{
+			name:       "nested object and array",
+			line:       `{"Timestamp":"2021-03-19T13:01:52.734Z","EventId":0,"LogLevel":"Information","Category":"Svc","Message":"msg","ElapsedMs":12,"State":{"Message":"msg","Scopes":["a","b"]}}`,
+			logMessage: &DotNetLogMessage{},
+			want:       Fields{"ElapsedMs": json.Number("12"), "State.Message": "msg", "State.Scopes": `["a","b"]`},
+		},
+		{
+			name:       "dotted keys of nested objects",
+			line:       `{"@timestamp":"2023-01-10T08:00:00.123Z","log":{"level":"INFO","origin":{"function":"main"}},"message":"ok","service.name":"orders"}`,
+			logMessage: &EcsLogMessage{},
+			want:       Fields{"log.origin.function": "main"},
+		},
+		{
+			name:       "custom mdc keys",
+			line:       `{"timestamp":"2020-07-14T09:38:14.977Z","level":"INFO","message":"sample output","loggerName":"org.acme.MyClass","mdc":{"traceId":"abc","spanId":"def","userId":"u1","tenant":"x"}}`,
+			logMessage: &QuarkusLogMessage{},
+			want:       Fields{"mdc.userId": "u1", "mdc.tenant": "x"},
+		},
+		{
+			name:       "recursive cause",
+			line:       `{"message":"failed","exception":{"exceptionType":"E","causedBy":{"exception":{"exceptionType":"C","causedBy":{"exception":{"exceptionType":"R"}}}}}}`,
+			logMessage: &QuarkusLogMessage{},
+			want:       Fields{},
+		},
+		{
+			name:       "unknown keys of nested structs",
+			line:       `{"time":"2023-08-04T16:09:59.5Z","level":"INFO","msg":"hello","source":{"function":"main.main","file":"main.go","line":12,"column":3}}`,
+			logMessage: &SlogLogMessage{},
+			want:       Fields{"source.column": json.Number("3")},
+		},
+		{
+			name:       "null nested struct",
+			line:       `{"level":30,"time":1700000000000,"msg":"x","err":null}`,
+			logMessage: &PinoLogMessage{},
+			want:       Fields{},
+		},
+		{
+			name:       "invalid json",
+			line:       "123",
+			logMessage: &DotNetLogMessage{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extraFields([]byte(tt.line), tt.logMessage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("extraFields() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
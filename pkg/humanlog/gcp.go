@@ -0,0 +1,199 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gcpTracePrefix separates the project from the trace id, e.g. projects/my-project/traces/06796866738c859f2f19b7cfb3214824
+const gcpTracePrefix = "/traces/"
+
+// GcpLogMessage Google Cloud Logging log message type, either structured json written to stdout
+// on GKE and Cloud Run or a LogEntry exported with gcloud
+type GcpLogMessage struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message,omitempty"`
+	// Timestamp is a RFC3339 string or an object with seconds and nanos
+	Timestamp      json.RawMessage    `json:"timestamp,omitempty"`
+	Time           string             `json:"time,omitempty"`
+	Trace          string             `json:"logging.googleapis.com/trace,omitempty"`
+	SpanID         string             `json:"logging.googleapis.com/spanId,omitempty"`
+	TraceSampled   bool               `json:"logging.googleapis.com/trace_sampled,omitempty"`
+	SourceLocation *GcpSourceLocation `json:"logging.googleapis.com/sourceLocation,omitempty"`
+	HTTPRequest    *GcpHTTPRequest    `json:"httpRequest,omitempty"`
+
+	// fields of an exported LogEntry
+	EntryTrace          string             `json:"trace,omitempty"`
+	EntrySpanID         string             `json:"spanId,omitempty"`
+	EntryTraceSampled   bool               `json:"traceSampled,omitempty"`
+	EntrySourceLocation *GcpSourceLocation `json:"sourceLocation,omitempty"`
+	TextPayload         string             `json:"textPayload,omitempty"`
+	JSONPayload         json.RawMessage    `json:"jsonPayload,omitempty"`
+	Resource            *GcpResource       `json:"resource,omitempty"`
+}
+
+// GcpSourceLocation is the location of the log statement
+type GcpSourceLocation struct {
+	File     string          `json:"file"`
+	Line     json.RawMessage `json:"line"`
+	Function string          `json:"function,omitempty"`
+}
+
+// GcpHTTPRequest is the request of an access log entry
+type GcpHTTPRequest struct {
+	RequestMethod string          `json:"requestMethod"`
+	RequestURL    string          `json:"requestUrl"`
+	Status        int             `json:"status"`
+	ResponseSize  json.RawMessage `json:"responseSize,omitempty"`
+	UserAgent     string          `json:"userAgent,omitempty"`
+	RemoteIP      string          `json:"remoteIp,omitempty"`
+	Latency       string          `json:"latency,omitempty"`
+	Protocol      string          `json:"protocol,omitempty"`
+}
+
+// GcpResource is the monitored resource of an exported LogEntry
+type GcpResource struct {
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (glm *GcpLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Level:    ParseLevel(glm.Severity),
+		RawLevel: glm.Severity,
+		Message:  glm.Message,
+		Fields:   Fields{},
+	}
+
+	e.Time, e.RawTime = parseGcpTimestamp(glm.Timestamp)
+	if e.RawTime == "" {
+		e.Time, e.RawTime = parseTime(glm.Time), glm.Time
+	}
+
+	e.Trace = Tracing{
+		TraceID: gcpTraceID(firstNonEmpty(glm.Trace, glm.EntryTrace)),
+		SpanID:  firstNonEmpty(glm.SpanID, glm.EntrySpanID),
+	}
+
+	if glm.TraceSampled || glm.EntryTraceSampled {
+		e.Trace.Sampled = "true"
+	}
+
+	location := glm.SourceLocation
+	if location == nil {
+		location = glm.EntrySourceLocation
+	}
+
+	if location != nil && location.File != "" {
+		e.Logger = location.File + ":" + strings.Trim(string(location.Line), `"`)
+
+		if location.Function != "" {
+			e.Fields["sourceLocation.function"] = location.Function
+		}
+	}
+
+	if glm.TextPayload != "" {
+		e.Message = glm.TextPayload
+	}
+
+	// the json payload of an exported LogEntry contains the message and the fields of the log statement
+	if len(glm.JSONPayload) > 0 {
+		payload, _ := decodeFields(glm.JSONPayload)
+
+		for _, key := range []string{"message", "msg"} {
+			if message, ok := payload[key].(string); ok && e.Message == "" {
+				e.Message = message
+				delete(payload, key)
+			}
+		}
+
+		for key, value := range payload {
+			e.Fields[key] = value
+		}
+	}
+
+	if glm.Resource != nil {
+		e.Service = firstNonEmpty(glm.Resource.Labels["service_name"], glm.Resource.Labels["container_name"])
+		e.Fields["resource.type"] = glm.Resource.Type
+
+		for key, value := range glm.Resource.Labels {
+			e.Fields["resource.labels."+key] = value
+		}
+	}
+
+	if glm.HTTPRequest != nil {
+		e.Message = strings.TrimSpace(e.Message + " " + glm.HTTPRequest.String())
+	}
+
+	if len(e.Fields) == 0 {
+		e.Fields = nil
+	}
+
+	return e
+}
+
+// String returns the request as compact access log line, e.g. GET /api 200 512B 12ms 10.0.0.1 "curl/8.0"
+func (r *GcpHTTPRequest) String() string {
+	parts := []string{r.RequestMethod, r.RequestURL}
+
+	if r.Status != 0 {
+		parts = append(parts, strconv.Itoa(r.Status))
+	}
+
+	if size := strings.Trim(string(r.ResponseSize), `"`); size != "" {
+		parts = append(parts, size+"B")
+	}
+
+	parts = append(parts, r.Latency, r.RemoteIP)
+
+	if r.UserAgent != "" {
+		parts = append(parts, strconv.Quote(r.UserAgent))
+	}
+
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
+
+// parseGcpTimestamp parses a RFC3339 string or a protobuf timestamp object
+func parseGcpTimestamp(raw json.RawMessage) (time.Time, string) {
+	var timestamp struct {
+		Seconds int64 `json:"seconds"`
+		Nanos   int64 `json:"nanos"`
+	}
+
+	if json.Unmarshal(raw, &timestamp) == nil && timestamp.Seconds > 0 {
+		return time.Unix(timestamp.Seconds, timestamp.Nanos).UTC(), fmt.Sprintf("%d.%09d", timestamp.Seconds, timestamp.Nanos)
+	}
+
+	return parseRawTime(raw)
+}
+
+// gcpTraceID returns the trace id of a trace resource name
+func gcpTraceID(trace string) string {
+	if i := strings.LastIndex(trace, gcpTracePrefix); i >= 0 {
+		return trace[i+len(gcpTracePrefix):]
+	}
+
+	return trace
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// isGcp reports whether the keys belong to Google Cloud Logging structured json or an exported LogEntry
+func isGcp(keys map[string]json.RawMessage) bool {
+	if hasKeys(keys, "jsonPayload") || hasKeys(keys, "textPayload") || hasKeys(keys, "insertId", "logName") {
+		return true
+	}
+
+	return hasKeys(keys, "severity") && (hasKeys(keys, "message") || hasKeys(keys, "httpRequest"))
+}
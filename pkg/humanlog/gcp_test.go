@@ -0,0 +1,137 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGcpLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Entry
+	}{
+		{
+			name: "structured json",
+			line: `{"severity":"ERROR","message":"payment failed","time":"2023-01-10T08:00:00.123Z","logging.googleapis.com/trace":"projects/p/traces/0679686673",` +
+				`"logging.googleapis.com/spanId":"004a","logging.googleapis.com/trace_sampled":true,"logging.googleapis.com/sourceLocation":{"file":"main.go","line":"42","function":"main.pay"}}`,
+			want: &Entry{
+				Time:     time.Date(2023, 1, 10, 8, 0, 0, 123000000, time.UTC),
+				RawTime:  "2023-01-10T08:00:00.123Z",
+				Level:    LevelError,
+				RawLevel: "ERROR",
+				Logger:   "main.go:42",
+				Message:  "payment failed",
+				Trace:    Tracing{TraceID: "0679686673", SpanID: "004a", Sampled: "true"},
+				Fields:   Fields{"sourceLocation.function": "main.pay"},
+			},
+		},
+		{
+			name: "exported log entry with json payload",
+			line: `{"insertId":"abc","jsonPayload":{"message":"request done","userId":12,"ctx":{"a":"b"}},"resource":{"type":"k8s_container","labels":{"container_name":"orders","pod_name":"orders-1"}},` +
+				`"timestamp":"2023-01-10T08:00:01.5Z","severity":"INFO","trace":"projects/p/traces/0679686673","sourceLocation":{"file":"app.py","line":7}}`,
+			want: &Entry{
+				Time:     time.Date(2023, 1, 10, 8, 0, 1, 500000000, time.UTC),
+				RawTime:  "2023-01-10T08:00:01.5Z",
+				Level:    LevelInfo,
+				RawLevel: "INFO",
+				Logger:   "app.py:7",
+				Message:  "request done",
+				Service:  "orders",
+				Trace:    Tracing{TraceID: "0679686673"},
+				Fields: Fields{
+					"userId":                         json.Number("12"),
+					"ctx.a":                          "b",
+					"resource.type":                  "k8s_container",
+					"resource.labels.container_name": "orders",
+					"resource.labels.pod_name":       "orders-1",
+				},
+			},
+		},
+		{
+			name: "request log with text payload and timestamp object",
+			line: `{"severity":"WARNING","textPayload":"upstream slow","timestamp":{"seconds":1673337600,"nanos":5000000},` +
+				`"httpRequest":{"requestMethod":"GET","requestUrl":"/api","status":503,"responseSize":"1234","userAgent":"curl/8.0","remoteIp":"10.0.0.1","latency":"0.123s"}}`,
+			want: &Entry{
+				Time:     time.Date(2023, 1, 10, 8, 0, 0, 5000000, time.UTC),
+				RawTime:  "1673337600.005000000",
+				Level:    LevelWarn,
+				RawLevel: "WARNING",
+				Message:  `upstream slow GET /api 503 1234B 0.123s 10.0.0.1 "curl/8.0"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glm := &GcpLogMessage{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.line), glm))
+			assert.Equal(t, tt.want, glm.toEntry())
+		})
+	}
+}
+
+func TestGcpHTTPRequest_String(t *testing.T) {
+	tests := []struct {
+		name string
+		r    GcpHTTPRequest
+		want string
+	}{
+		{
+			name: "complete",
+			r: GcpHTTPRequest{
+				RequestMethod: "POST",
+				RequestURL:    "https://x.run.app/orders",
+				Status:        201,
+				ResponseSize:  json.RawMessage(`512`),
+				UserAgent:     "Mozilla/5.0 (X11)",
+				RemoteIP:      "10.0.0.1",
+				Latency:       "0.012s",
+			},
+			want: `POST https://x.run.app/orders 201 512B 0.012s 10.0.0.1 "Mozilla/5.0 (X11)"`,
+		},
+		{
+			name: "minimal",
+			r:    GcpHTTPRequest{RequestMethod: "GET", RequestURL: "/health"},
+			want: "GET /health",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.String())
+		})
+	}
+}
+
+func TestGcp_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantFields Fields
+	}{
+		{
+			name:       "structured json",
+			line:       `{"severity":"INFO","message":"ok","time":"2023-01-10T08:00:00Z","logging.googleapis.com/labels":{"team":"shop"}}`,
+			wantFields: Fields{"logging.googleapis.com/labels.team": "shop"},
+		},
+		{
+			name:       "exported log entry",
+			line:       `{"insertId":"abc","textPayload":"ok","timestamp":"2023-01-10T08:00:00Z","logName":"projects/p/logs/stdout"}`,
+			wantFields: Fields{"insertId": "abc", "logName": "projects/p/logs/stdout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AutoParser{}.Parse([]byte(tt.line))
+			assert.NoError(t, err)
+			assert.Equal(t, "gcp", got.Format)
+			assert.Equal(t, "ok", got.Message)
+			assert.Equal(t, tt.wantFields, got.Fields)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package humanlog
+
+import "strings"
+
+// Level is the canonical severity of a log entry
+type Level int
+
+// Canonical levels in ascending severity
+const (
+	LevelUnknown Level = iota
+	LevelTrace
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var levelNames = map[Level]string{
+	LevelUnknown: "UNKNOWN",
+	LevelTrace:   "TRACE",
+	LevelDebug:   "DEBUG",
+	LevelInfo:    "INFO",
+	LevelWarn:    "WARN",
+	LevelError:   "ERROR",
+	LevelFatal:   "FATAL",
+}
+
+// levelVocabulary maps the level names of all formats to the canonical level
+var levelVocabulary = map[string]Level{
+	"trace":       LevelTrace,
+	"finest":      LevelTrace,
+	"finer":       LevelTrace,
+	"verbose":     LevelTrace,
+	"debug":       LevelDebug,
+	"fine":        LevelDebug,
+	"config":      LevelDebug,
+	"info":        LevelInfo,
+	"information": LevelInfo,
+	"notice":      LevelInfo,
+	"warn":        LevelWarn,
+	"warning":     LevelWarn,
+	"error":       LevelError,
+	"err":         LevelError,
+	"severe":      LevelError,
+	"dpanic":      LevelError,
+	"fatal":       LevelFatal,
+	"critical":    LevelFatal,
+	"panic":       LevelFatal,
+	"alert":       LevelFatal,
+	"emergency":   LevelFatal,
+}
+
+// ParseLevel maps a level name of any format to the canonical level,
+// LevelUnknown is returned for names which are not known
+func ParseLevel(name string) Level {
+	return levelVocabulary[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// String returns the canonical upper case name of the level
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+
+	return levelNames[LevelUnknown]
+}
+
+// levelFromValue maps the numeric level_value of logback/Spring Boot to the canonical level
+func levelFromValue(value int) Level {
+	switch {
+	case value <= 0:
+		return LevelUnknown
+	case value < 10000: //nolint:gomnd // logback DEBUG_INT
+		return LevelTrace
+	case value < 20000: //nolint:gomnd // logback INFO_INT
+		return LevelDebug
+	case value < 30000: //nolint:gomnd // logback WARN_INT
+		return LevelInfo
+	case value < 40000: //nolint:gomnd // logback ERROR_INT
+		return LevelWarn
+	case value < 50000: //nolint:gomnd // above logback ERROR_INT
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// LevelFilter drops entries by their canonical level
+type LevelFilter struct {
+	// Min drops all entries below this level, entries with an unknown level are kept
+	Min Level
+	// Levels only keeps entries with exactly one of these levels, if set
+	Levels []Level
+}
+
+// Match reports whether the entry passes the filter
+func (f LevelFilter) Match(e *Entry) bool {
+	if len(f.Levels) > 0 {
+		for _, level := range f.Levels {
+			if e.Level == level {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return e.Level == LevelUnknown || e.Level >= f.Min
+}
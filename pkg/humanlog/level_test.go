@@ -0,0 +1,77 @@
+package humanlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{name: "ERROR", want: LevelError},
+		{name: "dpanic", want: LevelError},
+		{name: "Information", want: LevelInfo},
+		{name: "Critical", want: LevelFatal},
+		{name: "EMERGENCY", want: LevelFatal},
+		{name: "warning", want: LevelWarn},
+		{name: "something", want: LevelUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseLevel(tt.name))
+		})
+	}
+}
+
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "WARN", LevelWarn.String())
+	assert.Equal(t, "UNKNOWN", Level(42).String())
+}
+
+func Test_levelFromValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int
+		want  Level
+	}{
+		{name: "missing", value: 0, want: LevelUnknown},
+		{name: "trace", value: 5000, want: LevelTrace},
+		{name: "debug", value: 10000, want: LevelDebug},
+		{name: "info", value: 20000, want: LevelInfo},
+		{name: "warn", value: 30000, want: LevelWarn},
+		{name: "error", value: 40000, want: LevelError},
+		{name: "off", value: 2147483647, want: LevelFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, levelFromValue(tt.value))
+		})
+	}
+}
+
+func TestLevelFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter LevelFilter
+		level  Level
+		want   bool
+	}{
+		{name: "no filter", filter: LevelFilter{}, level: LevelDebug, want: true},
+		{name: "below min level", filter: LevelFilter{Min: LevelInfo}, level: LevelDebug, want: false},
+		{name: "min level", filter: LevelFilter{Min: LevelInfo}, level: LevelInfo, want: true},
+		{name: "unknown level passes min level", filter: LevelFilter{Min: LevelError}, level: LevelUnknown, want: true},
+		{name: "exact level", filter: LevelFilter{Levels: []Level{LevelWarn, LevelFatal}}, level: LevelFatal, want: true},
+		{name: "not in levels", filter: LevelFilter{Levels: []Level{LevelWarn, LevelFatal}}, level: LevelError, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(&Entry{Level: tt.level}))
+		})
+	}
+}
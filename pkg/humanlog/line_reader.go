@@ -0,0 +1,66 @@
+package humanlog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// lineReader reads lines of any length, lines longer than max bytes are truncated
+type lineReader struct {
+	r         *bufio.Reader
+	max       int
+	line      []byte
+	truncated int
+	err       error
+}
+
+func newLineReader(r io.Reader, max int) *lineReader {
+	return &lineReader{r: bufio.NewReaderSize(r, 64*1024), max: max} //nolint:gomnd // read buffer size
+}
+
+// Scan reads the next line and reports whether a line was read
+func (l *lineReader) Scan() bool {
+	l.line = l.line[:0]
+	l.truncated = 0
+
+	for {
+		chunk, isPrefix, err := l.r.ReadLine()
+		if err != nil {
+			if err != io.EOF {
+				l.err = err
+				return false
+			}
+
+			// the last line without newline could end exactly at the end of the read buffer
+			return len(l.line) > 0 || l.truncated > 0
+		}
+
+		keep := len(chunk)
+		if l.max > 0 && len(l.line)+keep > l.max {
+			keep = l.max - len(l.line)
+		}
+
+		l.line = append(l.line, chunk[:keep]...)
+		l.truncated += len(chunk) - keep
+
+		if !isPrefix {
+			return true
+		}
+	}
+}
+
+// Bytes returns the current line, a marker is appended if the line was truncated.
+// The slice is only valid until the next call of Scan.
+func (l *lineReader) Bytes() []byte {
+	if l.truncated > 0 {
+		return append(l.line, fmt.Sprintf(" … [truncated %d bytes]", l.truncated)...)
+	}
+
+	return l.line
+}
+
+// Err returns the first error except io.EOF
+func (l *lineReader) Err() error {
+	return l.err
+}
@@ -0,0 +1,73 @@
+package humanlog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lineReader(t *testing.T) {
+	long := strings.Repeat("x", 3*1024*1024)
+	buffer := strings.Repeat("x", 64*1024)
+
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		want  []string
+	}{
+		{
+			name:  "lines",
+			input: "first\r\nsecond\nlast without newline",
+			want:  []string{"first", "second", "last without newline"},
+		},
+		{
+			name:  "line longer than the read buffer",
+			input: long + "\nnext\n",
+			want:  []string{long, "next"},
+		},
+		{
+			name:  "truncated",
+			input: "0123456789\nshort\n",
+			max:   4,
+			want:  []string{"0123 … [truncated 6 bytes]", "shor … [truncated 1 bytes]"},
+		},
+		{
+			name:  "truncated line longer than the read buffer",
+			input: long + "\nnext\n",
+			max:   3,
+			want:  []string{"xxx … [truncated 3145725 bytes]", "nex … [truncated 1 bytes]"},
+		},
+		{
+			name:  "last line without newline filling the read buffer",
+			input: buffer,
+			want:  []string{buffer},
+		},
+		{
+			name:  "truncated last line without newline filling the read buffer",
+			input: buffer,
+			max:   3,
+			want:  []string{"xxx … [truncated 65533 bytes]"},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLineReader(strings.NewReader(tt.input), tt.max)
+
+			var got []string
+			for l.Scan() {
+				got = append(got, string(l.Bytes()))
+			}
+
+			assert.NoError(t, l.Err())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
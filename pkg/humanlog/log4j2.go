@@ -0,0 +1,91 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Log4j2LogMessage log4j2 JsonLayout log message type, also written by the JsonLayout.json
+// template of the JsonTemplateLayout
+type Log4j2LogMessage struct {
+	Instant *Log4j2Instant `json:"instant,omitempty"`
+	// TimeMillis is written by log4j2 versions before 2.11 instead of the instant
+	TimeMillis float64       `json:"timeMillis,omitempty"`
+	Level      string        `json:"level"`
+	LoggerName string        `json:"loggerName"`
+	Message    string        `json:"message"`
+	Thrown     *Log4j2Thrown `json:"thrown,omitempty"`
+}
+
+// Log4j2Instant is the time of a log4j2 event with nanosecond precision
+type Log4j2Instant struct {
+	EpochSecond  int64 `json:"epochSecond"`
+	NanoOfSecond int64 `json:"nanoOfSecond"`
+}
+
+// Log4j2Thrown is the exception of a log4j2 event
+type Log4j2Thrown struct {
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+	// ExtendedStackTrace is an array of frames or a string if the stack trace is stringified
+	ExtendedStackTrace json.RawMessage `json:"extendedStackTrace,omitempty"`
+	Cause              *Log4j2Thrown   `json:"cause,omitempty"`
+}
+
+func (l4m *Log4j2LogMessage) toEntry() *Entry {
+	e := &Entry{
+		Level:    ParseLevel(l4m.Level),
+		RawLevel: l4m.Level,
+		Logger:   l4m.LoggerName,
+		Message:  l4m.Message,
+	}
+
+	switch {
+	case l4m.Instant != nil:
+		e.Time = time.Unix(l4m.Instant.EpochSecond, l4m.Instant.NanoOfSecond).UTC()
+		e.RawTime = strconv.FormatInt(l4m.Instant.EpochSecond, 10) + "." + strconv.FormatInt(l4m.Instant.NanoOfSecond, 10)
+	case l4m.TimeMillis > 0:
+		e.Time = parseEpoch(l4m.TimeMillis)
+		e.RawTime = strconv.FormatFloat(l4m.TimeMillis, 'f', -1, 64)
+	}
+
+	// log message contains an exception
+	if l4m.Thrown != nil {
+		e.Error = l4m.Thrown.toError()
+	}
+
+	return e
+}
+
+func (t *Log4j2Thrown) toError() *Error {
+	e := &Error{
+		Type:    t.Name,
+		Message: t.Message,
+	}
+
+	var frames []Frame
+
+	err := json.Unmarshal(t.ExtendedStackTrace, &frames)
+	if err == nil {
+		e.Frames = frames
+	} else {
+		// the stringified stack trace already contains name and message
+		var stacktrace string
+		if json.Unmarshal(t.ExtendedStackTrace, &stacktrace) == nil && stacktrace != "" {
+			return &Error{Stacktrace: stacktrace}
+		}
+	}
+
+	if t.Cause != nil {
+		e.Cause = t.Cause.toError()
+	}
+
+	return e
+}
+
+// isLog4j2 reports whether the keys belong to the log4j2 JsonLayout, which is
+// like Quarkus but without the timestamp key
+func isLog4j2(keys map[string]json.RawMessage) bool {
+	return hasKeys(keys, "loggerName") && (hasKeys(keys, "instant") || hasKeys(keys, "timeMillis"))
+}
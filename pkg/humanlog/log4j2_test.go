@@ -0,0 +1,73 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog4j2LogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Entry
+	}{
+		{
+			name: "instant with thrown and cause",
+			line: `{"instant":{"epochSecond":1526303934,"nanoOfSecond":123456789},"level":"ERROR","loggerName":"logtest.Main","message":"Boom",` +
+				`"thrown":{"name":"java.lang.RuntimeException","message":"outer","extendedStackTrace":[{"class":"logtest.Main","method":"main","file":"Main.java","line":29,"exact":true}],` +
+				`"cause":{"name":"java.io.IOException","message":"inner","extendedStackTrace":[{"class":"logtest.Io","method":"read","file":"Io.java","line":12}]}}}`,
+			want: &Entry{
+				Time:     time.Date(2018, 5, 14, 13, 18, 54, 123456789, time.UTC),
+				RawTime:  "1526303934.123456789",
+				Level:    LevelError,
+				RawLevel: "ERROR",
+				Logger:   "logtest.Main",
+				Message:  "Boom",
+				Error: &Error{
+					Type:    "java.lang.RuntimeException",
+					Message: "outer",
+					Frames:  []Frame{{Class: "logtest.Main", Method: "main", Line: 29}},
+					Cause: &Error{
+						Type:    "java.io.IOException",
+						Message: "inner",
+						Frames:  []Frame{{Class: "logtest.Io", Method: "read", Line: 12}},
+					},
+				},
+			},
+		},
+		{
+			name: "time millis with stringified stack trace",
+			line: `{"timeMillis":1526303934123,"level":"WARN","loggerName":"logtest.Main","message":"Retry",` +
+				`"thrown":{"name":"java.io.IOException","message":"inner","extendedStackTrace":"java.io.IOException: inner\n\tat logtest.Io.read(Io.java:12)"}}`,
+			want: &Entry{
+				Time:     time.Date(2018, 5, 14, 13, 18, 54, 123000000, time.UTC),
+				RawTime:  "1526303934123",
+				Level:    LevelWarn,
+				RawLevel: "WARN",
+				Logger:   "logtest.Main",
+				Message:  "Retry",
+				Error:    &Error{Stacktrace: "java.io.IOException: inner\n\tat logtest.Io.read(Io.java:12)"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l4m := &Log4j2LogMessage{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.line), l4m))
+			assert.Equal(t, tt.want, l4m.toEntry())
+		})
+	}
+}
+
+func TestLog4j2_Parse(t *testing.T) {
+	line := `{"instant":{"epochSecond":1526303934,"nanoOfSecond":0},"thread":"main","level":"INFO","loggerName":"logtest.Main","message":"Hello","endOfBatch":false,"threadId":1}`
+
+	got, err := AutoParser{}.Parse([]byte(line))
+	assert.NoError(t, err)
+	assert.Equal(t, "log4j2", got.Format)
+	assert.Equal(t, Fields{"thread": "main", "endOfBatch": false, "threadId": json.Number("1")}, got.Fields)
+}
@@ -0,0 +1,200 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// QuarkusLogMessage Quarkus Standard Log message type
+type QuarkusLogMessage struct {
+	Timestamp  string    `json:"timestamp"`
+	Level      string    `json:"level"`
+	Message    string    `json:"message"`
+	Exception  Exception `json:"exception,omitempty"`
+	LoggerName string    `json:"loggerName"`
+	Tracing    Tracing   `json:"mdc"`
+}
+
+// SpringBootLogMessage Spring Boot Log message type
+type SpringBootLogMessage struct {
+	Timestamp  string `json:"@timestamp"`
+	Level      string `json:"level"`
+	Message    string `json:"message"`
+	LevelValue int    `json:"level_value,omitempty"`
+	Exception  string `json:"stack_trace,omitempty"`
+	LoggerName string `json:"logger_name"`
+}
+
+// GoZapLogMessage Uber Zap log message type
+type GoZapLogMessage struct {
+	Level string `json:"level"`
+	// Timestamp is an epoch float by default or a string with an ISO8601 or RFC3339 time encoder
+	Timestamp  json.RawMessage `json:"ts"`
+	Logger     string          `json:"logger"`
+	Message    string          `json:"msg"`
+	Controller string          `json:"controller,omitempty"`
+	Request    string          `json:"request,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Stacktrace string          `json:"stacktrace,omitempty"`
+}
+
+// DotNetLogMessage type
+type DotNetLogMessage struct {
+	Timestamp  string `json:"Timestamp"`
+	EventID    int    `json:"EventId"`
+	Level      string `json:"LogLevel"`
+	Message    string `json:"Message"`
+	LoggerName string `json:"Category"`
+	// Exception is the result of Exception.ToString() including inner exceptions
+	Exception string `json:"Exception,omitempty"`
+	// Scopes are strings or objects with a Message and the scope values
+	Scopes []json.RawMessage `json:"Scopes,omitempty"`
+}
+
+// CommonLogMessage is implemented by all log message types to normalize them into an Entry
+type CommonLogMessage interface {
+	toEntry() *Entry
+}
+
+// Exception for Java Log message
+type Exception struct {
+	RefID         int      `json:"refId"`
+	ExceptionType string   `json:"exceptionType"`
+	Message       string   `json:"message"`
+	CausedBy      CausedBy `json:"causedBy"`
+	Frames        *[]Frame `json:"frames"`
+}
+
+// CausedBy Exception caused by filed
+type CausedBy struct {
+	Exception *Exception `json:"exception,omitempty"`
+}
+
+// Frame of a stack trace
+type Frame struct {
+	Class  string `json:"class"`
+	Method string `json:"method"`
+	Line   int    `json:"line"`
+}
+
+// Tracing context of a log message
+type Tracing struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+	Sampled string `json:"sampled"`
+}
+
+func (lm *QuarkusLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Time:     parseTime(lm.Timestamp),
+		RawTime:  lm.Timestamp,
+		Level:    ParseLevel(lm.Level),
+		RawLevel: lm.Level,
+		Logger:   lm.LoggerName,
+		Message:  lm.Message,
+		Trace:    lm.Tracing,
+	}
+
+	// log message contains an exception
+	if lm.Exception != (Exception{}) {
+		e.Error = lm.Exception.toError()
+	}
+
+	return e
+}
+
+func (ex *Exception) toError() *Error {
+	e := &Error{
+		Type:    ex.ExceptionType,
+		Message: ex.Message,
+	}
+
+	if ex.Frames != nil {
+		e.Frames = *ex.Frames
+	}
+
+	if ex.CausedBy.Exception != nil {
+		e.Cause = ex.CausedBy.Exception.toError()
+	}
+
+	return e
+}
+
+func (alm *SpringBootLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Time:     parseTime(alm.Timestamp),
+		RawTime:  alm.Timestamp,
+		Level:    ParseLevel(alm.Level),
+		RawLevel: alm.Level,
+		Logger:   alm.LoggerName,
+		Message:  alm.Message,
+	}
+
+	if e.Level == LevelUnknown {
+		e.Level = levelFromValue(alm.LevelValue)
+	}
+
+	// log message contains an exception
+	if alm.Exception != "" {
+		e.Error = &Error{Stacktrace: alm.Exception}
+	}
+
+	return e
+}
+
+func (glm *GoZapLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Level:    ParseLevel(glm.Level),
+		RawLevel: glm.Level,
+		Logger:   glm.Logger,
+		Message:  glm.Message,
+	}
+
+	e.Time, e.RawTime = parseRawTime(glm.Timestamp)
+
+	if glm.Controller != "" || glm.Request != "" {
+		e.Fields = Fields{}
+	}
+
+	if glm.Controller != "" {
+		e.Fields["controller"] = glm.Controller
+	}
+
+	if glm.Request != "" {
+		e.Fields["request"] = glm.Request
+	}
+
+	// log message contains an error
+	if glm.Error != "" || glm.Stacktrace != "" {
+		e.Error = &Error{Message: glm.Error, Stacktrace: glm.Stacktrace}
+	}
+
+	return e
+}
+
+func (dnlm *DotNetLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Time:     parseTime(dnlm.Timestamp),
+		RawTime:  dnlm.Timestamp,
+		Level:    ParseLevel(dnlm.Level),
+		RawLevel: dnlm.Level,
+		Logger:   dnlm.LoggerName,
+		Message:  dnlm.Message,
+	}
+
+	// same as the .NET simple console, the event id 0 means no event id
+	if dnlm.EventID != 0 {
+		e.Logger += "[" + strconv.Itoa(dnlm.EventID) + "]"
+	}
+
+	if len(dnlm.Scopes) > 0 {
+		e.Trace, e.Fields = dotNetScopes(dnlm.Scopes)
+	}
+
+	// log message contains an exception
+	if dnlm.Exception != "" {
+		e.Error = parseDotNetException(dnlm.Exception)
+	}
+
+	return e
+}
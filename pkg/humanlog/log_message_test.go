@@ -0,0 +1,427 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoZapLogMessage_toEntry(t *testing.T) {
+	type fields struct {
+		Level      string
+		Timestamp  json.RawMessage
+		Logger     string
+		Message    string
+		Error      string
+		Stacktrace string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Entry
+	}{
+		{
+			name: "ok",
+			fields: fields{
+				Level:      "error",
+				Timestamp:  json.RawMessage("1598445905.143377"),
+				Logger:     "controller-runtime.controller",
+				Message:    "Reconciler error",
+				Error:      "error getting scaler for trigger #0",
+				Stacktrace: "github.com/go-logr/zapr.(*zapLogger).Error\n\t/Users/zroubali/go/pkg/mod/github.com/go-logr/zapr@v0.1.1/zapr.go:128",
+			},
+			want: &Entry{
+				Time:     time.Unix(1598445905, 143377066).UTC(),
+				RawTime:  "1598445905.143377",
+				Level:    LevelError,
+				RawLevel: "error",
+				Logger:   "controller-runtime.controller",
+				Message:  "Reconciler error",
+				Error: &Error{
+					Message:    "error getting scaler for trigger #0",
+					Stacktrace: "github.com/go-logr/zapr.(*zapLogger).Error\n\t/Users/zroubali/go/pkg/mod/github.com/go-logr/zapr@v0.1.1/zapr.go:128",
+				},
+			},
+		},
+		{
+			name: "without controller and error",
+			fields: fields{
+				Level:     "info",
+				Timestamp: json.RawMessage("1598445905"),
+				Message:   "started",
+			},
+			want: &Entry{
+				Time:     time.Unix(1598445905, 0).UTC(),
+				RawTime:  "1598445905",
+				Level:    LevelInfo,
+				RawLevel: "info",
+				Message:  "started",
+			},
+		},
+		{
+			name: "iso8601 time encoder",
+			fields: fields{
+				Level:     "info",
+				Timestamp: json.RawMessage(`"2024-01-01T00:00:00.123Z"`),
+				Logger:    "L",
+				Message:   "zap iso",
+			},
+			want: &Entry{
+				Time:     time.Date(2024, 1, 1, 0, 0, 0, 123000000, time.UTC),
+				RawTime:  "2024-01-01T00:00:00.123Z",
+				Level:    LevelInfo,
+				RawLevel: "info",
+				Logger:   "L",
+				Message:  "zap iso",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glm := &GoZapLogMessage{
+				Level:      tt.fields.Level,
+				Timestamp:  tt.fields.Timestamp,
+				Logger:     tt.fields.Logger,
+				Message:    tt.fields.Message,
+				Error:      tt.fields.Error,
+				Stacktrace: tt.fields.Stacktrace,
+			}
+			assert.Equal(t, tt.want, glm.toEntry())
+		})
+	}
+}
+
+func TestDotNetLogMessage_toEntry(t *testing.T) {
+	type fields struct {
+		Timestamp  string
+		EventID    int
+		Level      string
+		Message    string
+		LoggerName string
+		Exception  string
+		Scopes     []json.RawMessage
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Entry
+	}{
+		{
+			name: "ok",
+			fields: fields{
+				Timestamp:  "2021-03-19T11:05:29.566Z",
+				Level:      "Information",
+				Message:    "GetUsers request received",
+				LoggerName: "UserManagementSvc.Controllers.UserManagementController",
+			},
+			want: &Entry{
+				Time:     time.Date(2021, 3, 19, 11, 5, 29, 566000000, time.UTC),
+				RawTime:  "2021-03-19T11:05:29.566Z",
+				Level:    LevelInfo,
+				RawLevel: "Information",
+				Logger:   "UserManagementSvc.Controllers.UserManagementController",
+				Message:  "GetUsers request received",
+			},
+		},
+		{
+			name: "with event id, scopes and exception",
+			fields: fields{
+				Timestamp:  "2021-03-19T11:05:29.566Z",
+				EventID:    12,
+				Level:      "Error",
+				Message:    "GetUsers request failed",
+				LoggerName: "UserManagementSvc.Controllers.UserManagementController",
+				Exception:  "System.Exception: failed\n   at UserManagementSvc.Controllers.UserManagementController.GetUsers() in /src/UserManagementController.cs:line 27",
+				Scopes: []json.RawMessage{
+					json.RawMessage(`{"Message":"SpanId:abc, TraceId:def, ParentId:000","SpanId":"abc","TraceId":"def","ParentId":"000"}`),
+					json.RawMessage(`"GET /users"`),
+				},
+			},
+			want: &Entry{
+				Time:     time.Date(2021, 3, 19, 11, 5, 29, 566000000, time.UTC),
+				RawTime:  "2021-03-19T11:05:29.566Z",
+				Level:    LevelError,
+				RawLevel: "Error",
+				Logger:   "UserManagementSvc.Controllers.UserManagementController[12]",
+				Message:  "GetUsers request failed",
+				Trace:    Tracing{TraceID: "def", SpanID: "abc"},
+				Fields:   Fields{"Scopes": "SpanId:abc, TraceId:def, ParentId:000 => GET /users"},
+				Error: &Error{
+					Type:    "System.Exception",
+					Message: "failed",
+					Frames: []Frame{{
+						Class:  "/src/UserManagementController.cs",
+						Method: "UserManagementSvc.Controllers.UserManagementController.GetUsers",
+						Line:   27,
+					}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dnlm := &DotNetLogMessage{
+				Timestamp:  tt.fields.Timestamp,
+				EventID:    tt.fields.EventID,
+				Level:      tt.fields.Level,
+				Message:    tt.fields.Message,
+				LoggerName: tt.fields.LoggerName,
+				Exception:  tt.fields.Exception,
+				Scopes:     tt.fields.Scopes,
+			}
+			assert.Equal(t, tt.want, dnlm.toEntry())
+		})
+	}
+}
+
+func TestSpringBootLogMessage_toEntry(t *testing.T) {
+	type fields struct {
+		Timestamp  string
+		Level      string
+		LevelValue int
+		Message    string
+		Exception  string
+		LoggerName string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Entry
+	}{
+		{
+			name: "ok",
+			fields: fields{
+				Timestamp:  "2020-07-15T19:09:39.983Z",
+				Level:      "INFO",
+				Message:    "My log message",
+				Exception:  "java.lang.NullPointerException: null\n\tat java.base/java.lang.Thread.run(Thread.java:834)\n",
+				LoggerName: "",
+			},
+			want: &Entry{
+				Time:     time.Date(2020, 7, 15, 19, 9, 39, 983000000, time.UTC),
+				RawTime:  "2020-07-15T19:09:39.983Z",
+				Level:    LevelInfo,
+				RawLevel: "INFO",
+				Message:  "My log message",
+				Error:    &Error{Stacktrace: "java.lang.NullPointerException: null\n\tat java.base/java.lang.Thread.run(Thread.java:834)\n"},
+			},
+		},
+		{
+			name: "level value",
+			fields: fields{
+				Timestamp:  "2020-07-15T19:09:39.983Z",
+				Level:      "CUSTOM",
+				LevelValue: 30000,
+				Message:    "My log message",
+				LoggerName: "org.acme.MyClass",
+			},
+			want: &Entry{
+				Time:     time.Date(2020, 7, 15, 19, 9, 39, 983000000, time.UTC),
+				RawTime:  "2020-07-15T19:09:39.983Z",
+				Level:    LevelWarn,
+				RawLevel: "CUSTOM",
+				Logger:   "org.acme.MyClass",
+				Message:  "My log message",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alm := &SpringBootLogMessage{
+				Timestamp:  tt.fields.Timestamp,
+				Level:      tt.fields.Level,
+				LevelValue: tt.fields.LevelValue,
+				Message:    tt.fields.Message,
+				Exception:  tt.fields.Exception,
+				LoggerName: tt.fields.LoggerName,
+			}
+			assert.Equal(t, tt.want, alm.toEntry())
+		})
+	}
+}
+
+func TestException_toError(t *testing.T) {
+	type fields struct {
+		RefID         int
+		ExceptionType string
+		Message       string
+		CausedBy      CausedBy
+		Frames        *[]Frame
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Error
+	}{
+		{
+			name: "ok",
+			fields: fields{
+				RefID:         0,
+				ExceptionType: "javax.jms.JMSRuntimeException",
+				Message:       "Unknown error from remote peer",
+				CausedBy: CausedBy{&Exception{
+					RefID:         1,
+					ExceptionType: "javax.jms.RuntimeException",
+					Message:       "Unknown error",
+					CausedBy:      CausedBy{},
+					Frames: &[]Frame{{
+						Class:  "org.apache.qpid.jms.exceptions.ExceptionSupport",
+						Method: "createRuntimeException",
+						Line:   212,
+					}},
+				}},
+				Frames: &[]Frame{{
+					Class:  "org.apache.qpid.jms.exceptions.JmsExceptionSupport",
+					Method: "createRuntimeException",
+					Line:   211,
+				}},
+			},
+			want: &Error{
+				Type:    "javax.jms.JMSRuntimeException",
+				Message: "Unknown error from remote peer",
+				Frames: []Frame{{
+					Class:  "org.apache.qpid.jms.exceptions.JmsExceptionSupport",
+					Method: "createRuntimeException",
+					Line:   211,
+				}},
+				Cause: &Error{
+					Type:    "javax.jms.RuntimeException",
+					Message: "Unknown error",
+					Frames: []Frame{{
+						Class:  "org.apache.qpid.jms.exceptions.ExceptionSupport",
+						Method: "createRuntimeException",
+						Line:   212,
+					}},
+				},
+			},
+		},
+		{
+			name: "without frames",
+			fields: fields{
+				ExceptionType: "java.lang.IllegalStateException",
+				Message:       "invalid",
+			},
+			want: &Error{
+				Type:    "java.lang.IllegalStateException",
+				Message: "invalid",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &Exception{
+				RefID:         tt.fields.RefID,
+				ExceptionType: tt.fields.ExceptionType,
+				Message:       tt.fields.Message,
+				CausedBy:      tt.fields.CausedBy,
+				Frames:        tt.fields.Frames,
+			}
+			assert.Equal(t, tt.want, ex.toError())
+		})
+	}
+}
+
+func TestQuarkusLogMessage_toEntry(t *testing.T) {
+	type fields struct {
+		Timestamp  string
+		Level      string
+		Message    string
+		Exception  Exception
+		LoggerName string
+		Tracing    Tracing
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Entry
+	}{
+		{
+			name: "With trace id and exception",
+			fields: fields{
+				Timestamp: "2020-07-15T19:09:39.983Z",
+				Level:     "INFO",
+				Message:   "My log message",
+				Exception: Exception{
+					RefID:         1,
+					ExceptionType: "javax.jms.RuntimeException",
+					Message:       "Unknown error",
+					CausedBy:      CausedBy{},
+					Frames: &[]Frame{{
+						Class:  "org.apache.qpid.jms.exceptions.ExceptionSupport",
+						Method: "createRuntimeException",
+						Line:   212,
+					}},
+				},
+				LoggerName: "org.acme.MyClass",
+				Tracing: Tracing{
+					TraceID: "123",
+					SpanID:  "12",
+					Sampled: "1",
+				},
+			},
+			want: &Entry{
+				Time:     time.Date(2020, 7, 15, 19, 9, 39, 983000000, time.UTC),
+				RawTime:  "2020-07-15T19:09:39.983Z",
+				Level:    LevelInfo,
+				RawLevel: "INFO",
+				Logger:   "org.acme.MyClass",
+				Message:  "My log message",
+				Error: &Error{
+					Type:    "javax.jms.RuntimeException",
+					Message: "Unknown error",
+					Frames: []Frame{{
+						Class:  "org.apache.qpid.jms.exceptions.ExceptionSupport",
+						Method: "createRuntimeException",
+						Line:   212,
+					}},
+				},
+				Trace: Tracing{
+					TraceID: "123",
+					SpanID:  "12",
+					Sampled: "1",
+				},
+			},
+		},
+		{
+			name: "Invalid timestamp",
+			fields: fields{
+				Timestamp:  "2020-07- 14T19:18:03.56Z",
+				Level:      "ERROR",
+				Message:    "My log message",
+				LoggerName: "org.acme.MyClass",
+			},
+			want: &Entry{
+				RawTime:  "2020-07- 14T19:18:03.56Z",
+				Level:    LevelError,
+				RawLevel: "ERROR",
+				Logger:   "org.acme.MyClass",
+				Message:  "My log message",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lm := &QuarkusLogMessage{
+				Timestamp:  tt.fields.Timestamp,
+				Level:      tt.fields.Level,
+				Message:    tt.fields.Message,
+				Exception:  tt.fields.Exception,
+				LoggerName: tt.fields.LoggerName,
+				Tracing:    tt.fields.Tracing,
+			}
+			assert.Equal(t, tt.want, lm.toEntry())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package humanlog
+
+// LogrusLogMessage sirupsen/logrus JSONFormatter log message type
+type LogrusLogMessage struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Message string `json:"msg"`
+	Error   string `json:"error,omitempty"`
+	// Func is the calling function if ReportCaller is enabled
+	Func string `json:"func,omitempty"`
+}
+
+func (llm *LogrusLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Time:     parseTime(llm.Time),
+		RawTime:  llm.Time,
+		Level:    ParseLevel(llm.Level),
+		RawLevel: llm.Level,
+		Logger:   llm.Func,
+		Message:  llm.Message,
+	}
+
+	// log message contains an error
+	if llm.Error != "" {
+		e.Error = &Error{Message: llm.Error}
+	}
+
+	return e
+}
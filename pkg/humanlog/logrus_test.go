@@ -0,0 +1,58 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogrusLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		llm  LogrusLogMessage
+		want *Entry
+	}{
+		{
+			name: "with error and caller",
+			llm: LogrusLogMessage{
+				Time:    "2021-03-19T13:01:52+01:00",
+				Level:   "warning",
+				Message: "retrying request",
+				Error:   "connection refused",
+				Func:    "main.fetch",
+			},
+			want: &Entry{
+				Time:     time.Date(2021, 3, 19, 13, 1, 52, 0, time.FixedZone("", 60*60)),
+				RawTime:  "2021-03-19T13:01:52+01:00",
+				Level:    LevelWarn,
+				RawLevel: "warning",
+				Logger:   "main.fetch",
+				Message:  "retrying request",
+				Error:    &Error{Message: "connection refused"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.llm.toEntry()
+			assert.True(t, tt.want.Time.Equal(got.Time))
+			got.Time = tt.want.Time
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLogrus_Parse(t *testing.T) {
+	line := `{"animal":"walrus","file":"/app/main.go:12","func":"main.main","level":"info","msg":"A walrus appears","size":10,"time":"2021-03-19T13:01:52Z"}`
+
+	got, err := AutoParser{}.Parse([]byte(line))
+	assert.NoError(t, err)
+	assert.Equal(t, "logrus", got.Format)
+	assert.Equal(t, LevelInfo, got.Level)
+	assert.Equal(t, "main.main", got.Logger)
+	assert.Equal(t, "A walrus appears", got.Message)
+	assert.Equal(t, Fields{"animal": "walrus", "file": "/app/main.go:12", "size": json.Number("10")}, got.Fields)
+}
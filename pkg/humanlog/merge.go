@@ -0,0 +1,216 @@
+package humanlog
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"io"
+	"time"
+)
+
+// sourceColors are used round robin for the labels of merged sources
+var sourceColors = []string{colorCyan, colorMagenta, colorYellow, colorGreen, colorBlue, colorRed}
+
+// Source is a named input of a Merger
+type Source struct {
+	// Label is written in front of every entry of this source
+	Label  string
+	Reader io.Reader
+}
+
+// Merger interleaves the entries of several sources chronologically
+type Merger struct {
+	Converter *Converter
+	// Tolerance is how far lines within a single source may be out of order,
+	// every source is read ahead for this duration to sort its lines
+	Tolerance time.Duration
+	// Color enables colored source labels
+	Color bool
+}
+
+// Merge reads all sources side by side and writes their entries ordered by time to w.
+// Lines without a timestamp keep the time of the previous line of their source.
+func (m *Merger) Merge(sources []Source, w io.Writer) error {
+	width := 0
+	for _, source := range sources {
+		if len(source.Label) > width {
+			width = len(source.Label)
+		}
+	}
+
+	cursors := &cursorHeap{}
+
+	for i, source := range sources {
+		c := &cursor{
+			records:   newContainerReader(newLineReader(source.Reader, m.Converter.MaxLineSize)),
+			parser:    m.Converter.Parser,
+			tolerance: m.Tolerance,
+			index:     i,
+			prefix:    paint(m.Color, sourceColors[i%len(sourceColors)], fmt.Sprintf("%-*s", width, source.Label)) + " ",
+		}
+
+		err := c.fill()
+		if err != nil {
+			return err
+		}
+
+		if c.pending.Len() > 0 {
+			heap.Push(cursors, c)
+		}
+	}
+
+	for cursors.Len() > 0 {
+		c := heap.Pop(cursors).(*cursor)
+		item := heap.Pop(&c.pending).(*mergeItem)
+
+		err := m.write(w, c.prefix, item)
+		if err != nil {
+			return err
+		}
+
+		err = c.fill()
+		if err != nil {
+			return err
+		}
+
+		if c.pending.Len() > 0 {
+			heap.Push(cursors, c)
+		}
+	}
+
+	return nil
+}
+
+// write renders the item with the source prefix in front of the first line
+func (m *Merger) write(w io.Writer, prefix string, item *mergeItem) error {
+	var buf bytes.Buffer
+
+	err := m.Converter.render(&buf, item.entry, item.line)
+	if err != nil || buf.Len() == 0 {
+		return err
+	}
+
+	_, err = io.WriteString(w, prefix)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf.Bytes())
+
+	return err
+}
+
+// mergeItem is a parsed line, entry is nil if the line could not be parsed
+type mergeItem struct {
+	entry *Entry
+	line  []byte
+	time  time.Time
+	seq   int
+}
+
+// cursor reads a single source ahead by the tolerance and keeps the read items sorted
+type cursor struct {
+	records   *containerReader
+	parser    Parser
+	tolerance time.Duration
+	index     int
+	prefix    string
+	pending   itemHeap
+	seq       int
+	last      time.Time
+	latest    time.Time
+	eof       bool
+}
+
+// fill reads lines until the next item could not be overtaken by a later line within the tolerance
+func (c *cursor) fill() error {
+	for !c.eof && (c.pending.Len() == 0 || c.pending[0].time.Add(c.tolerance).After(c.latest)) {
+		if !c.records.Scan() {
+			c.eof = true
+			return c.records.Err()
+		}
+
+		// records are not reused by the reader, so the line could be kept
+		record := c.records.Record()
+
+		for _, entry := range parseRecord(c.parser, record) {
+			c.push(&mergeItem{entry: entry, line: record.line}, record.time)
+		}
+	}
+
+	return nil
+}
+
+// push adds an item, items without a time keep the time of the container envelope
+// or the time of the previous item
+func (c *cursor) push(item *mergeItem, envelope time.Time) {
+	switch {
+	case item.entry != nil && !item.entry.Time.IsZero():
+		item.time = item.entry.Time
+	case !envelope.IsZero():
+		item.time = envelope
+	default:
+		item.time = c.last
+	}
+
+	item.seq = c.seq
+	c.seq++
+	c.last = item.time
+
+	if item.time.After(c.latest) {
+		c.latest = item.time
+	}
+
+	heap.Push(&c.pending, item)
+}
+
+// itemHeap orders the items of a source by time and read order
+type itemHeap []*mergeItem
+
+func (h itemHeap) Len() int { return len(h) }
+
+func (h itemHeap) Less(i, j int) bool {
+	if h[i].time.Equal(h[j].time) {
+		return h[i].seq < h[j].seq
+	}
+
+	return h[i].time.Before(h[j].time)
+}
+
+func (h itemHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *itemHeap) Push(x interface{}) { *h = append(*h, x.(*mergeItem)) }
+
+func (h *itemHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return item
+}
+
+// cursorHeap orders the sources by the time of their next item
+type cursorHeap []*cursor
+
+func (h cursorHeap) Len() int { return len(h) }
+
+func (h cursorHeap) Less(i, j int) bool {
+	a, b := h[i].pending[0], h[j].pending[0]
+	if a.time.Equal(b.time) {
+		return h[i].index < h[j].index
+	}
+
+	return a.time.Before(b.time)
+}
+
+func (h cursorHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *cursorHeap) Push(x interface{}) { *h = append(*h, x.(*cursor)) }
+
+func (h *cursorHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return c
+}
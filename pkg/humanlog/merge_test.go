@@ -0,0 +1,141 @@
+package humanlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMerger_Merge(t *testing.T) {
+	quarkus := `{"level":"INFO","timestamp":"2020-07-14T09:38:14.000Z","message":"q1","loggerName":"q"}
+{"level":"INFO","timestamp":"2020-07-14T09:38:16.000Z","message":"q2","loggerName":"q"}
+{"level":"INFO","timestamp":"2020-07-14T09:38:15.500Z","message":"q3 slightly out of order","loggerName":"q"}
+`
+	zap := `{"level":"info","ts":1594719495,"logger":"z","msg":"z1"}
+plain text after z1
+{"level":"info","ts":1594719497,"logger":"z","msg":"z2"}
+`
+
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		color     bool
+		wantW     string
+	}{
+		{
+			name:      "with tolerance",
+			tolerance: time.Second,
+			wantW: "quarkus.json INFO 2020-07-14T09:38:14.000Z\tq\tq1\n" +
+				"zap.json     INFO 2020-07-14T09:38:15.000Z\tz\tz1\n" +
+				"zap.json     plain text after z1\n" +
+				"quarkus.json INFO 2020-07-14T09:38:15.500Z\tq\tq3 slightly out of order\n" +
+				"quarkus.json INFO 2020-07-14T09:38:16.000Z\tq\tq2\n" +
+				"zap.json     INFO 2020-07-14T09:38:17.000Z\tz\tz2\n",
+		},
+		{
+			name:      "without tolerance",
+			tolerance: 0,
+			wantW: "quarkus.json INFO 2020-07-14T09:38:14.000Z\tq\tq1\n" +
+				"zap.json     INFO 2020-07-14T09:38:15.000Z\tz\tz1\n" +
+				"zap.json     plain text after z1\n" +
+				"quarkus.json INFO 2020-07-14T09:38:16.000Z\tq\tq2\n" +
+				"quarkus.json INFO 2020-07-14T09:38:15.500Z\tq\tq3 slightly out of order\n" +
+				"zap.json     INFO 2020-07-14T09:38:17.000Z\tz\tz2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Merger{Converter: NewConverter(), Tolerance: tt.tolerance, Color: tt.color}
+			w := &bytes.Buffer{}
+			err := m.Merge([]Source{
+				{Label: "quarkus.json", Reader: strings.NewReader(quarkus)},
+				{Label: "zap.json", Reader: strings.NewReader(zap)},
+			}, w)
+			if err != nil {
+				t.Errorf("Merge() error = %v", err)
+				return
+			}
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("Merge() = \n%v, want \n%v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestMerger_Merge_colorAndFilter(t *testing.T) {
+	c := NewConverter()
+	c.Levels = LevelFilter{Min: LevelWarn}
+	m := &Merger{Converter: c, Color: true}
+	w := &bytes.Buffer{}
+
+	err := m.Merge([]Source{
+		{Label: "a", Reader: strings.NewReader(`{"level":"INFO","timestamp":"2020-07-14T09:38:14.000Z","message":"hidden"}`)},
+		{Label: "b", Reader: strings.NewReader(`{"level":"WARN","timestamp":"2020-07-14T09:38:15.000Z","message":"shown"}`)},
+	}, w)
+	if err != nil {
+		t.Errorf("Merge() error = %v", err)
+		return
+	}
+
+	want := "\x1b[35mb\x1b[0m WARN 2020-07-14T09:38:15.000Z\t\tshown\n"
+	if gotW := w.String(); gotW != want {
+		t.Errorf("Merge() = %q, want %q", gotW, want)
+	}
+}
+
+func TestMerger_Merge_severalEntriesInALine(t *testing.T) {
+	quarkus := `{"level":"INFO","timestamp":"2020-07-14T09:38:15.000Z","message":"q1","loggerName":"q"}
+`
+	otlp := `{"resourceLogs":[{"scopeLogs":[{"scope":{"name":"o"},"logRecords":[` +
+		`{"timeUnixNano":"1594719494000000000","severityNumber":9,"body":{"stringValue":"o1"}},` +
+		`{"timeUnixNano":"1594719496000000000","severityNumber":9,"body":{"stringValue":"o2"}}]}]}]}
+`
+	want := "otlp.json    INFO 2020-07-14T09:38:14.000Z\to\to1\n" +
+		"quarkus.json INFO 2020-07-14T09:38:15.000Z\tq\tq1\n" +
+		"otlp.json    INFO 2020-07-14T09:38:16.000Z\to\to2\n"
+
+	m := &Merger{Converter: NewConverter(), Tolerance: time.Second}
+	w := &bytes.Buffer{}
+
+	err := m.Merge([]Source{
+		{Label: "quarkus.json", Reader: strings.NewReader(quarkus)},
+		{Label: "otlp.json", Reader: strings.NewReader(otlp)},
+	}, w)
+	if err != nil {
+		t.Errorf("Merge() error = %v", err)
+		return
+	}
+
+	if gotW := w.String(); gotW != want {
+		t.Errorf("Merge() = \n%v, want \n%v", gotW, want)
+	}
+}
+
+func TestMerger_Merge_containerEnvelopes(t *testing.T) {
+	quarkus := `{"level":"INFO","timestamp":"2020-07-14T09:38:15.000Z","message":"q1","loggerName":"q"}
+`
+	cri := `2020-07-14T09:38:14Z stdout F plain text at 14
+2020-07-14T09:38:16Z stdout F plain text at 16
+`
+	want := "cri.log      plain text at 14\n" +
+		"quarkus.json INFO 2020-07-14T09:38:15.000Z\tq\tq1\n" +
+		"cri.log      plain text at 16\n"
+
+	m := &Merger{Converter: NewConverter(), Tolerance: time.Second}
+	w := &bytes.Buffer{}
+
+	err := m.Merge([]Source{
+		{Label: "quarkus.json", Reader: strings.NewReader(quarkus)},
+		{Label: "cri.log", Reader: strings.NewReader(cri)},
+	}, w)
+	if err != nil {
+		t.Errorf("Merge() error = %v", err)
+		return
+	}
+
+	if gotW := w.String(); gotW != want {
+		t.Errorf("Merge() = \n%v, want \n%v", gotW, want)
+	}
+}
@@ -0,0 +1,227 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// otlpServiceName is the resource attribute of the service name
+const otlpServiceName = "service.name"
+
+// OtlpLogMessage OpenTelemetry OTLP/JSON logs export, every log record is a separate entry
+type OtlpLogMessage struct {
+	ResourceLogs []OtlpResourceLogs `json:"resourceLogs"`
+}
+
+// OtlpResourceLogs are the logs of a single resource, e.g. a service
+type OtlpResourceLogs struct {
+	Resource struct {
+		Attributes []OtlpKeyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeLogs []OtlpScopeLogs `json:"scopeLogs"`
+}
+
+// OtlpScopeLogs are the logs of an instrumentation scope, e.g. a logger
+type OtlpScopeLogs struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	LogRecords []OtlpLogRecord `json:"logRecords"`
+}
+
+// OtlpLogRecord is a single log record
+type OtlpLogRecord struct {
+	// TimeUnixNano is a string because of the 64 bit integer json mapping of protobuf, numbers are accepted as well
+	TimeUnixNano         json.Number    `json:"timeUnixNano,omitempty"`
+	ObservedTimeUnixNano json.Number    `json:"observedTimeUnixNano,omitempty"`
+	SeverityNumber       int            `json:"severityNumber,omitempty"`
+	SeverityText         string         `json:"severityText,omitempty"`
+	Body                 *OtlpAnyValue  `json:"body,omitempty"`
+	Attributes           []OtlpKeyValue `json:"attributes,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"`
+	SpanID               string         `json:"spanId,omitempty"`
+}
+
+// OtlpKeyValue is an attribute
+type OtlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value OtlpAnyValue `json:"value"`
+}
+
+// OtlpAnyValue is a value of a body or an attribute, only one of the fields is set
+type OtlpAnyValue struct {
+	StringValue *string      `json:"stringValue,omitempty"`
+	BoolValue   *bool        `json:"boolValue,omitempty"`
+	IntValue    *json.Number `json:"intValue,omitempty"`
+	DoubleValue *json.Number `json:"doubleValue,omitempty"`
+	BytesValue  *string      `json:"bytesValue,omitempty"`
+	ArrayValue  *struct {
+		Values []OtlpAnyValue `json:"values"`
+	} `json:"arrayValue,omitempty"`
+	KvlistValue *struct {
+		Values []OtlpKeyValue `json:"values"`
+	} `json:"kvlistValue,omitempty"`
+}
+
+// toEntry returns the first log record, use toEntries for all records
+func (olm *OtlpLogMessage) toEntry() *Entry {
+	entries := olm.toEntries()
+	if len(entries) == 0 {
+		return &Entry{}
+	}
+
+	return entries[0]
+}
+
+func (olm *OtlpLogMessage) toEntries() []*Entry {
+	var entries []*Entry
+
+	for _, resourceLogs := range olm.ResourceLogs {
+		service := ""
+		resource := Fields{}
+
+		for _, attribute := range resourceLogs.Resource.Attributes {
+			if attribute.Key == otlpServiceName {
+				service = attribute.Value.String()
+				continue
+			}
+
+			flatten(resource, "resource", map[string]interface{}{attribute.Key: attribute.Value.value()})
+		}
+
+		for _, scopeLogs := range resourceLogs.ScopeLogs {
+			for _, record := range scopeLogs.LogRecords {
+				e := record.toEntry()
+				e.Service = service
+				e.Logger = scopeLogs.Scope.Name
+
+				for key, value := range resource {
+					if e.Fields == nil {
+						e.Fields = Fields{}
+					}
+
+					e.Fields[key] = value
+				}
+
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	return entries
+}
+
+func (r *OtlpLogRecord) toEntry() *Entry {
+	e := &Entry{
+		Level:    levelFromSeverityNumber(r.SeverityNumber),
+		RawLevel: r.SeverityText,
+		Trace:    Tracing{TraceID: strings.ToLower(r.TraceID), SpanID: strings.ToLower(r.SpanID)},
+	}
+
+	if e.Level == LevelUnknown {
+		e.Level = ParseLevel(r.SeverityText)
+	}
+
+	if e.RawLevel == "" && r.SeverityNumber != 0 {
+		e.RawLevel = strconv.Itoa(r.SeverityNumber)
+	}
+
+	// the observed time is set by the collector if the source has no timestamp
+	for _, nanos := range []json.Number{r.TimeUnixNano, r.ObservedTimeUnixNano} {
+		n, err := nanos.Int64()
+		if err == nil && n > 0 {
+			e.Time, e.RawTime = time.Unix(0, n).UTC(), nanos.String()
+			break
+		}
+	}
+
+	if r.Body != nil {
+		e.Message = r.Body.String()
+	}
+
+	if len(r.Attributes) > 0 {
+		attributes := map[string]interface{}{}
+		for _, attribute := range r.Attributes {
+			attributes[attribute.Key] = attribute.Value.value()
+		}
+
+		e.Fields = Fields{}
+		flatten(e.Fields, "", attributes)
+	}
+
+	return e
+}
+
+// value returns the value as string, bool, json.Number, []interface{} or map[string]interface{}
+func (v *OtlpAnyValue) value() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		return *v.IntValue
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	case v.ArrayValue != nil:
+		values := make([]interface{}, 0, len(v.ArrayValue.Values))
+		for i := range v.ArrayValue.Values {
+			values = append(values, v.ArrayValue.Values[i].value())
+		}
+
+		return values
+	case v.KvlistValue != nil:
+		values := map[string]interface{}{}
+		for i := range v.KvlistValue.Values {
+			values[v.KvlistValue.Values[i].Key] = v.KvlistValue.Values[i].Value.value()
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
+
+// String returns strings unchanged and all other values as json
+func (v *OtlpAnyValue) String() string {
+	value := v.value()
+
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		b, _ := json.Marshal(value)
+		return string(b)
+	}
+}
+
+// levelFromSeverityNumber maps the OpenTelemetry severity number to the canonical level
+func levelFromSeverityNumber(number int) Level {
+	switch {
+	case number <= 0:
+		return LevelUnknown
+	case number <= 4: //nolint:gomnd // TRACE4
+		return LevelTrace
+	case number <= 8: //nolint:gomnd // DEBUG4
+		return LevelDebug
+	case number <= 12: //nolint:gomnd // INFO4
+		return LevelInfo
+	case number <= 16: //nolint:gomnd // WARN4
+		return LevelWarn
+	case number <= 20: //nolint:gomnd // ERROR4
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// isOtlp reports whether the keys belong to an OTLP/JSON logs export
+func isOtlp(keys map[string]json.RawMessage) bool {
+	return hasKeys(keys, "resourceLogs")
+}
@@ -0,0 +1,81 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOtlpLogMessage_toEntries(t *testing.T) {
+	line := `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}},{"key":"host.name","value":{"stringValue":"box"}}]},` +
+		`"scopeLogs":[{"scope":{"name":"my.library","version":"1.0.0"},"logRecords":[` +
+		`{"timeUnixNano":"1544712660300000000","severityNumber":10,"severityText":"Information","traceId":"5B8EFFF798038103D269B633813FC60C","spanId":"EEE19B7EC3C1B174",` +
+		`"body":{"stringValue":"Example log record"},"attributes":[{"key":"int.attribute","value":{"intValue":"10"}},{"key":"double.attribute","value":{"doubleValue":637.704}},` +
+		`{"key":"array.attribute","value":{"arrayValue":{"values":[{"stringValue":"many"},{"boolValue":true}]}}},` +
+		`{"key":"map.attribute","value":{"kvlistValue":{"values":[{"key":"some.map.key","value":{"stringValue":"some value"}}]}}}]},` +
+		`{"observedTimeUnixNano":1544712661000000000,"severityText":"WARN","body":{"kvlistValue":{"values":[{"key":"event","value":{"stringValue":"failed"}}]}}}]}]},` +
+		`{"scopeLogs":[{"logRecords":[{"severityNumber":21}]}]}]}`
+
+	want := []*Entry{
+		{
+			Time:     time.Date(2018, 12, 13, 14, 51, 0, 300000000, time.UTC),
+			RawTime:  "1544712660300000000",
+			Level:    LevelInfo,
+			RawLevel: "Information",
+			Logger:   "my.library",
+			Message:  "Example log record",
+			Service:  "checkout",
+			Trace:    Tracing{TraceID: "5b8efff798038103d269b633813fc60c", SpanID: "eee19b7ec3c1b174"},
+			Fields: Fields{
+				"int.attribute":              json.Number("10"),
+				"double.attribute":           json.Number("637.704"),
+				"array.attribute":            `["many",true]`,
+				"map.attribute.some.map.key": "some value",
+				"resource.host.name":         "box",
+			},
+		},
+		{
+			Time:     time.Date(2018, 12, 13, 14, 51, 1, 0, time.UTC),
+			RawTime:  "1544712661000000000",
+			Level:    LevelWarn,
+			RawLevel: "WARN",
+			Logger:   "my.library",
+			Message:  `{"event":"failed"}`,
+			Service:  "checkout",
+			Fields:   Fields{"resource.host.name": "box"},
+		},
+		{
+			Level:    LevelFatal,
+			RawLevel: "21",
+		},
+	}
+
+	olm := &OtlpLogMessage{}
+	assert.NoError(t, json.Unmarshal([]byte(line), olm))
+	assert.Equal(t, want, olm.toEntries())
+	assert.Equal(t, want[0], olm.toEntry())
+}
+
+func Test_levelFromSeverityNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		want   Level
+	}{
+		{name: "unspecified", number: 0, want: LevelUnknown},
+		{name: "trace", number: 1, want: LevelTrace},
+		{name: "debug4", number: 8, want: LevelDebug},
+		{name: "info", number: 9, want: LevelInfo},
+		{name: "warn", number: 13, want: LevelWarn},
+		{name: "error2", number: 18, want: LevelError},
+		{name: "fatal", number: 21, want: LevelFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, levelFromSeverityNumber(tt.number))
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package humanlog
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Parser turns a single log line into an Entry
+type Parser interface {
+	Parse(line []byte) (*Entry, error)
+}
+
+// MultiParser is implemented by parsers of formats with several log records in a single line,
+// e.g. OTLP. Parse returns the first entry of such a line.
+type MultiParser interface {
+	ParseAll(line []byte) ([]*Entry, error)
+}
+
+// ParseAll parses a line with ParseAll if the parser is a MultiParser, otherwise with Parse
+func ParseAll(p Parser, line []byte) ([]*Entry, error) {
+	if mp, ok := p.(MultiParser); ok {
+		return mp.ParseAll(line)
+	}
+
+	e, err := p.Parse(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*Entry{e}, nil
+}
+
+// multiLogMessage is implemented by log message types containing several log records
+type multiLogMessage interface {
+	toEntries() []*Entry
+}
+
+// Format describes a supported json log format
+type Format struct {
+	// Name of the format, e.g. "zap"
+	Name string
+	// detect reports whether the keys of a json object belong to this format
+	detect func(keys map[string]json.RawMessage) bool
+	// new returns an empty log message of this format to decode into
+	new func() CommonLogMessage
+	// defaultFields are rendered even if additional fields are not requested
+	defaultFields []string
+}
+
+// Formats contains all supported formats in the order they are detected.
+// Lines which match no format are not parsed and written unchanged.
+var Formats = []*Format{
+	{
+		Name:   "zap",
+		detect: func(keys map[string]json.RawMessage) bool { return hasKeys(keys, "ts", "msg") },
+		new:    func() CommonLogMessage { return &GoZapLogMessage{} },
+		// controller-runtime context which was always part of the zap output
+		defaultFields: []string{"controller", "request"},
+	},
+	{
+		Name:   "springboot",
+		detect: func(keys map[string]json.RawMessage) bool { return hasKeys(keys, "@timestamp", "logger_name") },
+		new:    func() CommonLogMessage { return &SpringBootLogMessage{} },
+	},
+	{
+		Name:   "dotnet",
+		detect: func(keys map[string]json.RawMessage) bool { return hasKeys(keys, "LogLevel", "Category") },
+		new:    func() CommonLogMessage { return &DotNetLogMessage{} },
+		// the scopes are shown like the .NET console formatters do
+		defaultFields: []string{"Scopes"},
+	},
+	{
+		Name:   "clef",
+		detect: isClef,
+		new:    func() CommonLogMessage { return &ClefLogMessage{} },
+	},
+	{
+		Name:   "pino",
+		detect: isPino,
+		new:    func() CommonLogMessage { return &PinoLogMessage{} },
+	},
+	{
+		Name:   "zerolog",
+		detect: isZerolog,
+		new:    func() CommonLogMessage { return &ZerologLogMessage{} },
+	},
+	{
+		Name:   "slog",
+		detect: isSlog,
+		new:    func() CommonLogMessage { return &SlogLogMessage{} },
+	},
+	{
+		Name:   "logrus",
+		detect: func(keys map[string]json.RawMessage) bool { return hasKeys(keys, "time", "level", "msg") },
+		new:    func() CommonLogMessage { return &LogrusLogMessage{} },
+	},
+	{
+		Name:   "otlp",
+		detect: isOtlp,
+		new:    func() CommonLogMessage { return &OtlpLogMessage{} },
+	},
+	{
+		Name:   "cloudwatch",
+		detect: isCloudWatch,
+		new:    func() CommonLogMessage { return &CloudWatchLogMessage{} },
+	},
+	{
+		Name:   "gcp",
+		detect: isGcp,
+		new:    func() CommonLogMessage { return &GcpLogMessage{} },
+	},
+	{
+		Name:   "log4j2",
+		detect: isLog4j2,
+		new:    func() CommonLogMessage { return &Log4j2LogMessage{} },
+	},
+	{
+		Name:   "ecs",
+		detect: isEcs,
+		new:    func() CommonLogMessage { return &EcsLogMessage{} },
+	},
+	{
+		Name:   "quarkus",
+		detect: isQuarkus,
+		new:    func() CommonLogMessage { return &QuarkusLogMessage{} },
+	},
+}
+
+// FormatByName returns the format with the given name
+func FormatByName(name string) (*Format, error) {
+	for _, f := range Formats {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+
+	return nil, errors.Errorf("unknown log format %q", name)
+}
+
+// Parse decodes the line with this format regardless of its keys
+func (f *Format) Parse(line []byte) (*Entry, error) {
+	entries, err := f.ParseAll(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries[0], nil
+}
+
+// ParseAll decodes the line with this format and returns all log records of the line,
+// a line without log records is an error
+func (f *Format) ParseAll(line []byte) ([]*Entry, error) {
+	logMessage := f.new()
+
+	err := json.Unmarshal(line, logMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	if mlm, ok := logMessage.(multiLogMessage); ok {
+		entries = mlm.toEntries()
+	} else {
+		entries = []*Entry{logMessage.toEntry()}
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.Errorf("no %s log records found", f.Name)
+	}
+
+	extra, err := extraFields(line, logMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		e.Format = f.Name
+
+		if len(extra) > 0 && e.Fields == nil {
+			e.Fields = Fields{}
+		}
+
+		for key, value := range extra {
+			e.Fields[key] = value
+		}
+	}
+
+	return entries, nil
+}
+
+// AutoParser sniffs the keys of every line and parses it with the first
+// matching format of Formats
+type AutoParser struct{}
+
+// Parse detects the format of the line and decodes it
+func (AutoParser) Parse(line []byte) (*Entry, error) {
+	f, err := detectLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Parse(line)
+}
+
+// ParseAll detects the format of the line and decodes all of its log records
+func (AutoParser) ParseAll(line []byte) ([]*Entry, error) {
+	f, err := detectLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.ParseAll(line)
+}
+
+func detectLine(line []byte) (*Format, error) {
+	var keys map[string]json.RawMessage
+
+	err := json.Unmarshal(line, &keys)
+	if err != nil {
+		return nil, err
+	}
+
+	f := Detect(keys)
+	if f == nil {
+		return nil, errors.New("unknown log format")
+	}
+
+	return f, nil
+}
+
+// Detect returns the first format matching the keys of a json object or nil if none matches
+func Detect(keys map[string]json.RawMessage) *Format {
+	for _, f := range Formats {
+		if f.detect(keys) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// isQuarkus reports whether the keys belong to a Quarkus log message, it is detected last
+// as its keys are common to many formats
+func isQuarkus(keys map[string]json.RawMessage) bool {
+	return hasKeys(keys, "loggerName") || hasKeys(keys, "timestamp", "message")
+}
+
+func hasKeys(keys map[string]json.RawMessage, names ...string) bool {
+	for _, name := range names {
+		if _, ok := keys[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,177 @@
+package humanlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoParser_Parse(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantFormat  string
+		wantMessage string
+		wantErr     bool
+	}{
+		{
+			name:        "uber zap",
+			line:        `{"level":"info","ts":1598445905.143377,"msg":"zap message"}`,
+			wantFormat:  "zap",
+			wantMessage: "zap message",
+		},
+		{
+			name:        "spring boot",
+			line:        `{"@timestamp":"2020-07-15T19:09:39.983Z","level":"INFO","logger_name":"org.acme.MyClass","message":"spring message"}`,
+			wantFormat:  "springboot",
+			wantMessage: "spring message",
+		},
+		{
+			name:        "dotnet",
+			line:        `{"Timestamp":"2021-03-19T13:01:52.734Z","LogLevel":"Information","Category":"UserManagementSvc","Message":"dotnet message"}`,
+			wantFormat:  "dotnet",
+			wantMessage: "dotnet message",
+		},
+		{
+			name:        "quarkus",
+			line:        `{"level":"INFO","timestamp":"2020-07-14T09:38:14.977Z","message":"sample output"}`,
+			wantFormat:  "quarkus",
+			wantMessage: "sample output",
+		},
+		{
+			name:        "quarkus logger name",
+			line:        `{"level":"INFO","loggerName":"org.acme.MyClass","message":"sample output"}`,
+			wantFormat:  "quarkus",
+			wantMessage: "sample output",
+		},
+		{
+			name:    "unknown json object",
+			line:    `{"foo":"bar"}`,
+			wantErr: true,
+		},
+		{
+			name:    "no json object",
+			line:    "123",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AutoParser{}.Parse([]byte(tt.line))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Equal(t, tt.wantFormat, got.Format)
+				assert.Equal(t, tt.wantMessage, got.Message)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	otlp := `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"body":{"stringValue":"first"}},{"body":{"stringValue":"second"}}]}]}]}`
+
+	tests := []struct {
+		name         string
+		parser       Parser
+		line         string
+		wantMessages []string
+		wantErr      bool
+	}{
+		{
+			name:         "several records",
+			parser:       AutoParser{},
+			line:         otlp,
+			wantMessages: []string{"first", "second"},
+		},
+		{
+			name:         "single record",
+			parser:       AutoParser{},
+			line:         `{"level":"info","ts":1598445905.143377,"msg":"zap message"}`,
+			wantMessages: []string{"zap message"},
+		},
+		{
+			name:         "parser without ParseAll",
+			parser:       singleParser{},
+			line:         otlp,
+			wantMessages: []string{"first"},
+		},
+		{
+			name:    "no log records",
+			parser:  AutoParser{},
+			line:    `{"resourceLogs":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			parser:  AutoParser{},
+			line:    "plain text",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAll(tt.parser, []byte(tt.line))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var messages []string
+			for _, e := range got {
+				messages = append(messages, e.Message)
+			}
+
+			assert.Equal(t, tt.wantMessages, messages)
+		})
+	}
+}
+
+// singleParser hides the ParseAll method of the AutoParser
+type singleParser struct{}
+
+func (singleParser) Parse(line []byte) (*Entry, error) {
+	return AutoParser{}.Parse(line)
+}
+
+func TestFormat_Parse_noRecords(t *testing.T) {
+	f, err := FormatByName("otlp")
+	assert.NoError(t, err)
+
+	_, err = f.Parse([]byte(`{"resourceLogs":[]}`))
+	assert.Error(t, err)
+}
+
+func TestFormatByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "zap",
+			want: "zap",
+		},
+		{
+			name:    "unknown",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatByName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Equal(t, tt.want, got.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// PinoLogMessage Node.js pino and bunyan log message type
+type PinoLogMessage struct {
+	// Level is numeric from 10 (trace) to 60 (fatal), pino could be configured to write the label instead
+	Level json.RawMessage `json:"level"`
+	// Time is epoch milliseconds for pino and an ISO string for bunyan
+	Time    json.RawMessage `json:"time,omitempty"`
+	Name    string          `json:"name,omitempty"`
+	Message string          `json:"msg"`
+	Err     *PinoError      `json:"err,omitempty"`
+}
+
+// PinoError is a serialized javascript Error
+type PinoError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	// Stack contains the type, message and all frames
+	Stack string `json:"stack"`
+}
+
+func (plm *PinoLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Logger:  plm.Name,
+		Message: plm.Message,
+	}
+
+	e.Time, e.RawTime = parseRawTime(plm.Time)
+	e.Level, e.RawLevel = parsePinoLevel(plm.Level)
+
+	// log message contains an error, the stack already starts with type and message
+	if plm.Err != nil {
+		if plm.Err.Stack != "" {
+			e.Error = &Error{Stacktrace: plm.Err.Stack}
+		} else {
+			e.Error = &Error{Type: plm.Err.Type, Message: plm.Err.Message}
+		}
+	}
+
+	return e
+}
+
+// parsePinoLevel maps a numeric pino/bunyan level or a level label to the canonical level
+func parsePinoLevel(raw json.RawMessage) (Level, string) {
+	var label string
+
+	err := json.Unmarshal(raw, &label)
+	if err == nil {
+		return ParseLevel(label), label
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return LevelUnknown, string(raw)
+	}
+
+	return levelFromPinoValue(value), strconv.Itoa(value)
+}
+
+// levelFromPinoValue maps the numeric pino/bunyan level to the canonical level
+func levelFromPinoValue(value int) Level {
+	switch {
+	case value <= 0:
+		return LevelUnknown
+	case value < 20: //nolint:gomnd // pino debug
+		return LevelTrace
+	case value < 30: //nolint:gomnd // pino info
+		return LevelDebug
+	case value < 40: //nolint:gomnd // pino warn
+		return LevelInfo
+	case value < 50: //nolint:gomnd // pino error
+		return LevelWarn
+	case value < 60: //nolint:gomnd // pino fatal
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// isPino reports whether the keys are written by pino or bunyan, which are the only formats
+// with a numeric level and "msg". Pino configured with formatters.level writes the level label,
+// it is still recognized by its epoch time, pid or hostname.
+func isPino(keys map[string]json.RawMessage) bool {
+	if !hasKeys(keys, "level", "msg") {
+		return false
+	}
+
+	var level, epoch json.Number
+
+	if json.Unmarshal(keys["level"], &level) == nil {
+		return true
+	}
+
+	return json.Unmarshal(keys["time"], &epoch) == nil || hasKeys(keys, "pid") || hasKeys(keys, "hostname")
+}
@@ -0,0 +1,132 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinoLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		plm  PinoLogMessage
+		want *Entry
+	}{
+		{
+			name: "pino with error stack",
+			plm: PinoLogMessage{
+				Level:   json.RawMessage(`50`),
+				Time:    json.RawMessage(`1531171074631`),
+				Message: "request failed",
+				Err: &PinoError{
+					Type:    "Error",
+					Message: "failed",
+					Stack:   "Error: failed\n    at Object.<anonymous> (/app/index.js:3:9)",
+				},
+			},
+			want: &Entry{
+				Time:     time.Date(2018, 7, 9, 21, 17, 54, 631000000, time.UTC),
+				RawTime:  "1531171074631",
+				Level:    LevelError,
+				RawLevel: "50",
+				Message:  "request failed",
+				Error:    &Error{Stacktrace: "Error: failed\n    at Object.<anonymous> (/app/index.js:3:9)"},
+			},
+		},
+		{
+			name: "bunyan",
+			plm: PinoLogMessage{
+				Level:   json.RawMessage(`20`),
+				Time:    json.RawMessage(`"2012-02-03T19:02:57.534Z"`),
+				Name:    "myapp",
+				Message: "hi",
+				Err:     &PinoError{Type: "TypeError", Message: "x is undefined"},
+			},
+			want: &Entry{
+				Time:     time.Date(2012, 2, 3, 19, 2, 57, 534000000, time.UTC),
+				RawTime:  "2012-02-03T19:02:57.534Z",
+				Level:    LevelDebug,
+				RawLevel: "20",
+				Logger:   "myapp",
+				Message:  "hi",
+				Error:    &Error{Type: "TypeError", Message: "x is undefined"},
+			},
+		},
+		{
+			name: "level label",
+			plm:  PinoLogMessage{Level: json.RawMessage(`"warn"`), Message: "labels"},
+			want: &Entry{Level: LevelWarn, RawLevel: "warn", Message: "labels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.plm.toEntry()
+			assert.True(t, tt.want.Time.Equal(got.Time))
+			got.Time = tt.want.Time
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_levelFromPinoValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int
+		want  Level
+	}{
+		{name: "missing", value: 0, want: LevelUnknown},
+		{name: "trace", value: 10, want: LevelTrace},
+		{name: "debug", value: 20, want: LevelDebug},
+		{name: "info", value: 30, want: LevelInfo},
+		{name: "custom", value: 35, want: LevelInfo},
+		{name: "warn", value: 40, want: LevelWarn},
+		{name: "error", value: 50, want: LevelError},
+		{name: "fatal", value: 60, want: LevelFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, levelFromPinoValue(tt.value))
+		})
+	}
+}
+
+func TestPino_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantLevel  Level
+		wantFields Fields
+	}{
+		{
+			name:       "numeric level",
+			line:       `{"level":30,"time":1531171074631,"pid":657,"hostname":"box","msg":"hello"}`,
+			wantLevel:  LevelInfo,
+			wantFields: Fields{"hostname": "box", "pid": json.Number("657")},
+		},
+		{
+			name:      "level label with epoch time",
+			line:      `{"level":"info","time":1700000000000,"msg":"x"}`,
+			wantLevel: LevelInfo,
+		},
+		{
+			name:       "level label with hostname",
+			line:       `{"level":"warn","hostname":"box","msg":"x"}`,
+			wantLevel:  LevelWarn,
+			wantFields: Fields{"hostname": "box"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AutoParser{}.Parse([]byte(tt.line))
+			assert.NoError(t, err)
+			assert.Equal(t, "pino", got.Format)
+			assert.Equal(t, tt.wantLevel, got.Level)
+			assert.Equal(t, tt.wantFields, got.Fields)
+		})
+	}
+}
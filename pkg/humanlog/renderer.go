@@ -0,0 +1,85 @@
+package humanlog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Renderer writes an Entry in a human readable form
+type Renderer interface {
+	Render(w io.Writer, e *Entry) error
+}
+
+// TextRenderer writes entries as tab separated plain text
+type TextRenderer struct {
+	// Color enables ANSI colored output
+	Color bool
+	// Time formats the timestamps, DefaultTimeFormat in the time zone of the log line is used if nil
+	Time *TimeFormatter
+}
+
+// Render writes the entry as plain text followed by its error, if any
+func (r TextRenderer) Render(w io.Writer, e *Entry) error {
+	fmt.Fprintf(w, "%v %v\t", paint(r.Color, levelColors[e.Level], e.LevelName()), paint(r.Color, colorDim, r.Time.Format(e)))
+
+	// log contains a tracing message
+	if e.Trace.TraceID != "" {
+		fmt.Fprintf(w, "traceId=%v ", e.Trace.TraceID)
+	}
+
+	if e.Service != "" {
+		fmt.Fprintf(w, "service=%v ", e.Service)
+	}
+
+	// stdout is the default stream of a container, only stderr is worth mentioning
+	if e.Stream == "stderr" {
+		fmt.Fprintf(w, "%v ", paint(r.Color, colorRed, "stream=stderr"))
+	}
+
+	fmt.Fprintf(w, "%v\t%v", paint(r.Color, colorCyan, e.Logger), e.Message)
+
+	if len(e.Fields) > 0 {
+		fmt.Fprintf(w, "\t%v", paint(r.Color, colorDim, e.Fields.String()))
+	}
+
+	fmt.Fprintln(w)
+
+	if e.Error != nil {
+		r.writeError(w, e.Error)
+	}
+
+	return nil
+}
+
+func (r TextRenderer) writeError(w io.Writer, e *Error) {
+	switch {
+	case e.Type != "" || len(e.Frames) > 0:
+		heading := "Caused by:"
+		if e.Type != "" {
+			heading += " " + e.Type + "."
+		}
+
+		fmt.Fprintf(w, "%s %s:\n", paint(r.Color, colorBold+colorRed, heading), e.Message)
+
+		for _, frame := range e.Frames {
+			// frames without source location, e.g. .NET without debug symbols
+			if frame.Class == "" {
+				fmt.Fprintf(w, "\t at %s\n", frame.Method)
+				continue
+			}
+
+			fmt.Fprintf(w, "\t at %s(%s:%v)\n", frame.Method, frame.Class, frame.Line)
+		}
+	case e.Message != "":
+		fmt.Fprintf(w, "%s %s\n", paint(r.Color, colorBold+colorRed, "error:"), e.Message)
+	}
+
+	if e.Stacktrace != "" {
+		fmt.Fprintln(w, strings.TrimRight(e.Stacktrace, "\n"))
+	}
+
+	if e.Cause != nil {
+		r.writeError(w, e.Cause)
+	}
+}
@@ -0,0 +1,158 @@
+package humanlog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestTextRenderer_Render(t *testing.T) {
+	ts := time.Date(2020, 7, 15, 19, 9, 39, 983000000, time.UTC)
+
+	tests := []struct {
+		name     string
+		renderer TextRenderer
+		entry    *Entry
+		wantW    string
+	}{
+		{
+			name: "with trace id and exception",
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelInfo,
+				Logger:  "org.acme.MyClass",
+				Message: "My log message",
+				Trace:   Tracing{TraceID: "123"},
+				Error: &Error{
+					Type:    "javax.jms.JMSRuntimeException",
+					Message: "Unknown error from remote peer",
+					Frames: []Frame{{
+						Class:  "org.apache.qpid.jms.exceptions.JmsExceptionSupport",
+						Method: "createRuntimeException",
+						Line:   211,
+					}},
+					Cause: &Error{
+						Type:    "javax.jms.RuntimeException",
+						Message: "Unknown error",
+						Frames: []Frame{{
+							Class:  "org.apache.qpid.jms.exceptions.ExceptionSupport",
+							Method: "createRuntimeException",
+							Line:   212,
+						}},
+					},
+				},
+			},
+			wantW: "INFO 2020-07-15T19:09:39.983Z\ttraceId=123 org.acme.MyClass\tMy log message\n" +
+				"Caused by: javax.jms.JMSRuntimeException. Unknown error from remote peer:\n" +
+				"\t at createRuntimeException(org.apache.qpid.jms.exceptions.JmsExceptionSupport:211)\n" +
+				"Caused by: javax.jms.RuntimeException. Unknown error:\n" +
+				"\t at createRuntimeException(org.apache.qpid.jms.exceptions.ExceptionSupport:212)\n",
+		},
+		{
+			name: "with fields and error message",
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelError,
+				Logger:  "controller-runtime.controller",
+				Message: "Reconciler error",
+				Fields:  Fields{"request": "default/queue", "controller": "scaledobject-controller"},
+				Error: &Error{
+					Message:    "error getting scaler",
+					Stacktrace: "github.com/go-logr/zapr.(*zapLogger).Error\n\t/go/pkg/mod/github.com/go-logr/zapr@v0.1.1/zapr.go:128",
+				},
+			},
+			wantW: "ERROR 2020-07-15T19:09:39.983Z\tcontroller-runtime.controller\tReconciler error\tcontroller=scaledobject-controller request=default/queue\n" +
+				"error: error getting scaler\n" +
+				"github.com/go-logr/zapr.(*zapLogger).Error\n\t/go/pkg/mod/github.com/go-logr/zapr@v0.1.1/zapr.go:128\n",
+		},
+		{
+			name: "with service",
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelInfo,
+				Logger:  "com.acme.Orders",
+				Message: "ok",
+				Service: "orders",
+				Trace:   Tracing{TraceID: "4bf92f"},
+			},
+			wantW: "INFO 2020-07-15T19:09:39.983Z\ttraceId=4bf92f service=orders com.acme.Orders\tok\n",
+		},
+		{
+			name: "stderr of a container",
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelWarn,
+				Logger:  "z",
+				Message: "retrying",
+				Stream:  "stderr",
+			},
+			wantW: "WARN 2020-07-15T19:09:39.983Z\tstream=stderr z\tretrying\n",
+		},
+		{
+			name: "stdout of a container",
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelInfo,
+				Logger:  "z",
+				Message: "ok",
+				Stream:  "stdout",
+			},
+			wantW: "INFO 2020-07-15T19:09:39.983Z\tz\tok\n",
+		},
+		{
+			name: "frames without source location",
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelError,
+				Logger:  "App.Worker[12]",
+				Message: "Job failed",
+				Error: &Error{
+					Type:    "System.InvalidOperationException",
+					Message: "Job failed",
+					Frames:  []Frame{{Method: "App.Worker.Execute"}},
+				},
+			},
+			wantW: "ERROR 2020-07-15T19:09:39.983Z\tApp.Worker[12]\tJob failed\n" +
+				"Caused by: System.InvalidOperationException. Job failed:\n" +
+				"\t at App.Worker.Execute\n",
+		},
+		{
+			name: "unknown level and raw timestamp",
+			entry: &Entry{
+				RawTime:  "2020-07- 14T19:18:03.56Z",
+				RawLevel: "SEVERE-ISH",
+				Message:  "My log message",
+				Error:    &Error{Stacktrace: "java.lang.NullPointerException: null\n\tat java.base/java.lang.Thread.run(Thread.java:834)\n"},
+			},
+			wantW: "SEVERE-ISH 2020-07- 14T19:18:03.56Z\t\tMy log message\n" +
+				"java.lang.NullPointerException: null\n\tat java.base/java.lang.Thread.run(Thread.java:834)\n",
+		},
+		{
+			name:     "colored",
+			renderer: TextRenderer{Color: true},
+			entry: &Entry{
+				Time:    ts,
+				Level:   LevelWarn,
+				Logger:  "org.acme.MyClass",
+				Message: "My log message",
+				Fields:  Fields{"thread": "main"},
+				Error:   &Error{Message: "failed"},
+			},
+			wantW: "\x1b[33mWARN\x1b[0m \x1b[2m2020-07-15T19:09:39.983Z\x1b[0m\t\x1b[36morg.acme.MyClass\x1b[0m\tMy log message\t\x1b[2mthread=main\x1b[0m\n" +
+				"\x1b[1m\x1b[31merror:\x1b[0m failed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			if err := tt.renderer.Render(w, tt.entry); err != nil {
+				t.Errorf("Render() error = %v", err)
+				return
+			}
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("Render() = \n%v, want \n%v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+)
+
+// slogLevelPattern matches the level names of log/slog including an offset, e.g. INFO+2 or DEBUG-4
+var slogLevelPattern = regexp.MustCompile(`^(DEBUG|INFO|WARN|ERROR)([+-][0-9]+)?$`)
+
+// slogLevelValues are the numeric values of the slog levels
+var slogLevelValues = map[string]int{
+	"DEBUG": -4,
+	"INFO":  0,
+	"WARN":  4,
+	"ERROR": 8,
+}
+
+// SlogLogMessage Go log/slog JSONHandler log message type,
+// attributes and groups are flattened into the additional fields
+type SlogLogMessage struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Message string `json:"msg"`
+	// Source is only written if AddSource is enabled
+	Source *SlogSource `json:"source,omitempty"`
+}
+
+// SlogSource is the location of the log statement
+type SlogSource struct {
+	Function string `json:"function,omitempty"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+func (slm *SlogLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Time:     parseTime(slm.Time),
+		RawTime:  slm.Time,
+		Level:    parseSlogLevel(slm.Level),
+		RawLevel: slm.Level,
+		Message:  slm.Message,
+	}
+
+	if slm.Source != nil && slm.Source.File != "" {
+		e.Logger = slm.Source.File + ":" + strconv.Itoa(slm.Source.Line)
+
+		if slm.Source.Function != "" {
+			e.Fields = Fields{"source.function": slm.Source.Function}
+		}
+	}
+
+	return e
+}
+
+// isSlog reports whether the keys are written by log/slog, which is recognized
+// by the source object or the upper case level names
+func isSlog(keys map[string]json.RawMessage) bool {
+	if !hasKeys(keys, "time", "level", "msg") {
+		return false
+	}
+
+	if _, ok := keys["source"]; ok {
+		return true
+	}
+
+	var level string
+
+	err := json.Unmarshal(keys["level"], &level)
+
+	return err == nil && slogLevelPattern.MatchString(level)
+}
+
+// parseSlogLevel maps a slog level with an optional offset to the canonical level
+// by its numeric value, e.g. INFO+2 is still info and DEBUG-4 is trace
+func parseSlogLevel(name string) Level {
+	match := slogLevelPattern.FindStringSubmatch(name)
+	if match == nil {
+		return ParseLevel(name)
+	}
+
+	value := slogLevelValues[match[1]]
+
+	if match[2] != "" {
+		offset, err := strconv.Atoi(match[2])
+		if err != nil {
+			return LevelUnknown
+		}
+
+		value += offset
+	}
+
+	switch {
+	case value < slogLevelValues["DEBUG"]:
+		return LevelTrace
+	case value < slogLevelValues["INFO"]:
+		return LevelDebug
+	case value < slogLevelValues["WARN"]:
+		return LevelInfo
+	case value < slogLevelValues["ERROR"]:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
@@ -0,0 +1,121 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		slm  SlogLogMessage
+		want *Entry
+	}{
+		{
+			name: "with source",
+			slm: SlogLogMessage{
+				Time:    "2023-08-04T16:09:59.123456789+02:00",
+				Level:   "INFO+2",
+				Message: "hello",
+				Source:  &SlogSource{Function: "main.main", File: "/app/main.go", Line: 14},
+			},
+			want: &Entry{
+				Time:     time.Date(2023, 8, 4, 16, 9, 59, 123456789, time.FixedZone("", 2*60*60)),
+				RawTime:  "2023-08-04T16:09:59.123456789+02:00",
+				Level:    LevelInfo,
+				RawLevel: "INFO+2",
+				Logger:   "/app/main.go:14",
+				Message:  "hello",
+				Fields:   Fields{"source.function": "main.main"},
+			},
+		},
+		{
+			name: "without source",
+			slm: SlogLogMessage{
+				Time:    "2023-08-04T16:09:59Z",
+				Level:   "DEBUG-4",
+				Message: "details",
+			},
+			want: &Entry{
+				Time:     time.Date(2023, 8, 4, 16, 9, 59, 0, time.UTC),
+				RawTime:  "2023-08-04T16:09:59Z",
+				Level:    LevelTrace,
+				RawLevel: "DEBUG-4",
+				Message:  "details",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.slm.toEntry()
+			assert.True(t, tt.want.Time.Equal(got.Time))
+			got.Time = tt.want.Time
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseSlogLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{name: "DEBUG", want: LevelDebug},
+		{name: "DEBUG-4", want: LevelTrace},
+		{name: "DEBUG+3", want: LevelDebug},
+		{name: "INFO", want: LevelInfo},
+		{name: "INFO+2", want: LevelInfo},
+		{name: "INFO-1", want: LevelDebug},
+		{name: "WARN", want: LevelWarn},
+		{name: "WARN+4", want: LevelError},
+		{name: "ERROR+8", want: LevelError},
+		{name: "info", want: LevelInfo},
+		{name: "custom", want: LevelUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseSlogLevel(tt.name))
+		})
+	}
+}
+
+func TestSlog_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantFormat string
+		wantFields Fields
+	}{
+		{
+			name:       "groups",
+			line:       `{"time":"2023-08-04T16:09:59Z","level":"WARN","msg":"request failed","req":{"method":"GET","url":"/api"},"status":500}`,
+			wantFormat: "slog",
+			wantFields: Fields{"req.method": "GET", "req.url": "/api", "status": json.Number("500")},
+		},
+		{
+			name:       "source with lower case level",
+			line:       `{"time":"2023-08-04T16:09:59Z","level":"custom","msg":"request failed","source":{"function":"main.run","file":"main.go","line":7}}`,
+			wantFormat: "slog",
+			wantFields: Fields{"source.function": "main.run"},
+		},
+		{
+			name:       "logrus",
+			line:       `{"time":"2023-08-04T16:09:59Z","level":"warning","msg":"request failed"}`,
+			wantFormat: "logrus",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AutoParser{}.Parse([]byte(tt.line))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, got.Format)
+			assert.Equal(t, tt.wantFields, got.Fields)
+		})
+	}
+}
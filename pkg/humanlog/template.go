@@ -0,0 +1,99 @@
+package humanlog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TemplatePresets are named templates which could be used instead of a template text
+var TemplatePresets = map[string]string{
+	"compact": `{{.Time | fmt "15:04:05"}} {{.LevelName | pad 5}} {{.Message}}`,
+	"short":   `{{.Time | fmt "15:04:05.000"}} {{.LevelName | pad 5}} {{.Logger}}: {{.Message}}`,
+	"verbose": `{{.Timestamp}} {{.LevelName | pad 5}} [{{.Logger}}]{{with .Trace.TraceID}} traceId={{.}}{{end}}{{with .Service}} service={{.}}{{end}} {{.Message}}{{with .Fields}} {{.}}{{end}}`,
+	"message": `{{.Message}}`,
+}
+
+// templateFuncs are available in all templates
+var templateFuncs = template.FuncMap{
+	// fmt formats a time with a Go time layout, e.g. {{.Time | fmt "15:04:05"}}
+	"fmt": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	// pad right pads a value with spaces to the given width, e.g. {{.Level | pad 5}}
+	"pad": func(width int, v interface{}) string {
+		return fmt.Sprintf("%-*v", width, v)
+	},
+	"upper": func(v interface{}) string {
+		return strings.ToUpper(fmt.Sprint(v))
+	},
+	"lower": func(v interface{}) string {
+		return strings.ToLower(fmt.Sprint(v))
+	},
+}
+
+// TemplateRenderer writes entries with a user defined Go text/template,
+// the error of an entry is written below like by the TextRenderer
+type TemplateRenderer struct {
+	// Color enables ANSI colored errors, the template output itself is written as is
+	Color bool
+	// Time formats .Timestamp and converts .Time to its location, if set
+	Time *TimeFormatter
+
+	template *template.Template
+}
+
+// templateData is passed to the template, Time and Timestamp are already converted by the TimeFormatter
+type templateData struct {
+	*Entry
+	Time      time.Time
+	Timestamp string
+}
+
+// NewTemplateRenderer parses the template text or uses the preset with this name
+func NewTemplateRenderer(text string) (*TemplateRenderer, error) {
+	if preset, ok := TemplatePresets[text]; ok {
+		text = preset
+	}
+
+	t, err := template.New("entry").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid template")
+	}
+
+	return &TemplateRenderer{template: t}, nil
+}
+
+// Render executes the template for the entry
+func (r *TemplateRenderer) Render(w io.Writer, e *Entry) error {
+	var buf bytes.Buffer
+
+	err := r.template.Execute(&buf, templateData{
+		Entry:     e,
+		Time:      r.Time.Time(e),
+		Timestamp: r.Time.Format(e),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not execute template")
+	}
+
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if e.Error != nil {
+		TextRenderer{Color: r.Color, Time: r.Time}.writeError(w, e.Error)
+	}
+
+	return nil
+}
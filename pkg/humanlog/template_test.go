@@ -0,0 +1,102 @@
+package humanlog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestTemplateRenderer_Render(t *testing.T) {
+	entry := &Entry{
+		Time:    time.Date(2020, 7, 15, 19, 9, 39, 983000000, time.UTC),
+		Level:   LevelInfo,
+		Logger:  "org.acme.MyClass",
+		Message: "My log message",
+		Trace:   Tracing{TraceID: "123"},
+		Fields:  Fields{"thread": "main"},
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		formatter *TimeFormatter
+		entry     *Entry
+		wantW     string
+		wantErr   bool
+	}{
+		{
+			name:  "custom template",
+			text:  `{{.Time | fmt "15:04:05"}} {{.Level | pad 5}} {{.Message}} {{.Fields}}`,
+			entry: entry,
+			wantW: "19:09:39 INFO  My log message thread=main\n",
+		},
+		{
+			name:  "compact preset",
+			text:  "compact",
+			entry: entry,
+			wantW: "19:09:39 INFO  My log message\n",
+		},
+		{
+			name:  "short preset with unknown level",
+			text:  "short",
+			entry: &Entry{Time: entry.Time, RawLevel: "NOTICE", Logger: "com.acme.Orders", Message: "ok"},
+			wantW: "19:09:39.983 NOTICE com.acme.Orders: ok\n",
+		},
+		{
+			name:  "verbose preset",
+			text:  "verbose",
+			entry: entry,
+			wantW: "2020-07-15T19:09:39.983Z INFO  [org.acme.MyClass] traceId=123 My log message thread=main\n",
+		},
+		{
+			name:  "verbose preset with service",
+			text:  "verbose",
+			entry: &Entry{Time: entry.Time, Level: LevelInfo, Logger: "com.acme.Orders", Message: "ok", Service: "orders"},
+			wantW: "2020-07-15T19:09:39.983Z INFO  [com.acme.Orders] service=orders ok\n",
+		},
+		{
+			name: "error is written below",
+			text: `{{.Message | upper}}`,
+			entry: &Entry{
+				Message: "failed",
+				Error:   &Error{Message: "connection refused"},
+			},
+			wantW: "FAILED\nerror: connection refused\n",
+		},
+		{
+			name:      "time formatter",
+			text:      `{{.Time | fmt "15:04"}} {{.Timestamp}}`,
+			formatter: &TimeFormatter{Layout: TimeLayout("kitchen"), Location: time.FixedZone("CEST", 2*60*60)},
+			entry:     entry,
+			wantW:     "21:09 9:09PM\n",
+		},
+		{
+			name:    "invalid template",
+			text:    `{{.Message`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			text:    `{{.Unknown}}`,
+			entry:   entry,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewTemplateRenderer(tt.text)
+			if err == nil {
+				r.Time = tt.formatter
+				w := &bytes.Buffer{}
+				err = r.Render(w, tt.entry)
+				if gotW := w.String(); err == nil && gotW != tt.wantW {
+					t.Errorf("Render() = \n%v, want \n%v", gotW, tt.wantW)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package humanlog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Special time layouts of the TimeFormatter
+const (
+	// TimeRelative renders the time relative to now, e.g. "3s ago"
+	TimeRelative = "relative"
+	// TimeDelta renders the duration since the previous entry, e.g. "+1.5s"
+	TimeDelta = "delta"
+)
+
+// timeLayouts are the named layouts which could be used as time format
+var timeLayouts = map[string]string{
+	"default":     DefaultTimeFormat,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"rfc1123":     time.RFC1123,
+	"rfc822":      time.RFC822,
+	"ansic":       time.ANSIC,
+	"kitchen":     time.Kitchen,
+	"stamp":       time.Stamp,
+	"stampmilli":  time.StampMilli,
+	"datetime":    "2006-01-02 15:04:05",
+	"time":        "15:04:05.000",
+}
+
+// timeParseLayouts are tried in this order to parse timestamps without a time zone as UTC
+var timeParseLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05,999999999",
+}
+
+// TimeLayout returns the Go time layout for a named layout like "RFC3339" or "kitchen",
+// any other value is returned unchanged
+func TimeLayout(name string) string {
+	if layout, ok := timeLayouts[strings.ToLower(name)]; ok {
+		return layout
+	}
+
+	return name
+}
+
+// TimeFormatter renders the time of entries
+type TimeFormatter struct {
+	// Layout is a Go time layout, TimeRelative or TimeDelta, DefaultTimeFormat is used if empty
+	Layout string
+	// Location the time is converted to, the time zone of the log line is kept if nil
+	Location *time.Location
+	// Now returns the current time for TimeRelative, time.Now is used if nil
+	Now func() time.Time
+
+	previous time.Time
+}
+
+// Time returns the time of the entry in the configured location
+func (f *TimeFormatter) Time(e *Entry) time.Time {
+	if f == nil || f.Location == nil || e.Time.IsZero() {
+		return e.Time
+	}
+
+	return e.Time.In(f.Location)
+}
+
+// Format renders the time of the entry, the raw timestamp is returned if it could not be parsed
+func (f *TimeFormatter) Format(e *Entry) string {
+	if f == nil || e.Time.IsZero() {
+		return e.Timestamp()
+	}
+
+	t := f.Time(e)
+
+	switch f.Layout {
+	case "":
+		return t.Format(DefaultTimeFormat)
+	case TimeRelative:
+		now := time.Now
+		if f.Now != nil {
+			now = f.Now
+		}
+
+		return relative(now().Sub(t))
+	case TimeDelta:
+		delta := time.Duration(0)
+		if !f.previous.IsZero() {
+			delta = t.Sub(f.previous)
+		}
+
+		f.previous = t
+
+		if delta < 0 {
+			return delta.String()
+		}
+
+		return "+" + delta.String()
+	default:
+		return t.Format(f.Layout)
+	}
+}
+
+// relative renders a duration in its largest unit, e.g. "3s ago" or "in 2m"
+func relative(d time.Duration) string {
+	format := "%v ago"
+	if d < 0 {
+		format = "in %v"
+		d = -d
+	}
+
+	var value string
+
+	switch {
+	case d < time.Second:
+		value = d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		value = fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		value = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		value = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		value = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+
+	return fmt.Sprintf(format, value)
+}
+
+// parseTime parses RFC3339 timestamps, timestamps without time zone as UTC
+// and numeric epoch timestamps, the zero time is returned if this is not possible
+func parseTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range timeParseLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t
+		}
+	}
+
+	epoch, err := strconv.ParseFloat(value, 64)
+	if err == nil {
+		return parseEpoch(epoch)
+	}
+
+	return time.Time{}
+}
+
+// parseEpoch converts an epoch timestamp in seconds, milliseconds, microseconds
+// or nanoseconds to UTC, the unit is guessed by the magnitude
+func parseEpoch(epoch float64) time.Time {
+	var unit time.Duration
+
+	switch {
+	case epoch <= 0:
+		return time.Time{}
+	case epoch < 1e11: //nolint:gomnd // seconds until the year 5138
+		unit = time.Second
+	case epoch < 1e14: //nolint:gomnd // milliseconds
+		unit = time.Millisecond
+	case epoch < 1e17: //nolint:gomnd // microseconds
+		unit = time.Microsecond
+	default:
+		unit = time.Nanosecond
+	}
+
+	whole, fraction := math.Modf(epoch)
+
+	return time.Unix(0, int64(whole)*int64(unit)+int64(math.Round(fraction*float64(unit)))).UTC()
+}
@@ -0,0 +1,153 @@
+package humanlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		{
+			name:  "RFC3339",
+			value: "2020-07-14T09:38:14.977Z",
+			want:  time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC),
+		},
+		{
+			name:  "RFC3339 with offset",
+			value: "2020-07-14T11:38:14.977+02:00",
+			want:  time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC),
+		},
+		{
+			name:  "ISO without zone",
+			value: "2020-07-14T09:38:14.977",
+			want:  time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC),
+		},
+		{
+			name:  "space separated",
+			value: "2020-07-14 09:38:14,977",
+			want:  time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC),
+		},
+		{
+			name:  "epoch seconds",
+			value: "1594719494",
+			want:  time.Date(2020, 7, 14, 9, 38, 14, 0, time.UTC),
+		},
+		{
+			name:  "epoch millis",
+			value: "1594719494977",
+			want:  time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC),
+		},
+		{
+			name:  "invalid",
+			value: "2020-07- 14T19:18:03.56Z",
+			want:  time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(parseTime(tt.value)), "parseTime() = %v, want %v", parseTime(tt.value), tt.want)
+		})
+	}
+}
+
+func Test_parseEpoch(t *testing.T) {
+	want := time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC)
+
+	tests := []struct {
+		name  string
+		epoch float64
+		want  time.Time
+	}{
+		{name: "seconds", epoch: 1594719494.977, want: want},
+		{name: "milliseconds", epoch: 1594719494977, want: want},
+		{name: "microseconds", epoch: 1594719494977000, want: want},
+		{name: "nanoseconds", epoch: 1594719494977000000, want: want},
+		{name: "zero", epoch: 0, want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseEpoch(tt.epoch)
+			assert.WithinDuration(t, tt.want, got, time.Millisecond)
+		})
+	}
+}
+
+func TestTimeFormatter_Format(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	now := time.Date(2020, 7, 14, 9, 38, 20, 0, time.UTC)
+	entries := []*Entry{
+		{Time: time.Date(2020, 7, 14, 9, 38, 14, 977000000, time.UTC)},
+		{Time: time.Date(2020, 7, 14, 9, 38, 16, 477000000, time.UTC)},
+	}
+
+	tests := []struct {
+		name      string
+		formatter *TimeFormatter
+		want      []string
+	}{
+		{
+			name:      "nil formatter",
+			formatter: nil,
+			want:      []string{"2020-07-14T09:38:14.977Z", "2020-07-14T09:38:16.477Z"},
+		},
+		{
+			name:      "kitchen in time zone",
+			formatter: &TimeFormatter{Layout: TimeLayout("Kitchen"), Location: berlin},
+			want:      []string{"11:38AM", "11:38AM"},
+		},
+		{
+			name:      "custom layout",
+			formatter: &TimeFormatter{Layout: "15:04:05.000", Location: time.UTC},
+			want:      []string{"09:38:14.977", "09:38:16.477"},
+		},
+		{
+			name:      "relative",
+			formatter: &TimeFormatter{Layout: TimeRelative, Now: func() time.Time { return now }},
+			want:      []string{"5s ago", "3s ago"},
+		},
+		{
+			name:      "delta",
+			formatter: &TimeFormatter{Layout: TimeDelta},
+			want:      []string{"+0s", "+1.5s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, e := range entries {
+				assert.Equal(t, tt.want[i], tt.formatter.Format(e))
+			}
+		})
+	}
+}
+
+func Test_relative(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{name: "milliseconds", d: 250 * time.Millisecond, want: "250ms ago"},
+		{name: "minutes", d: 150 * time.Second, want: "2m ago"},
+		{name: "days", d: 50 * time.Hour, want: "2d ago"},
+		{name: "future", d: -3 * time.Hour, want: "in 3h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relative(tt.d))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package humanlog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ZerologLogMessage rs/zerolog log message type
+type ZerologLogMessage struct {
+	// Time is either a RFC3339 string or a unix timestamp, depending on zerolog.TimeFieldFormat
+	Time    json.RawMessage `json:"time,omitempty"`
+	Level   string          `json:"level"`
+	Message string          `json:"message"`
+	Caller  string          `json:"caller,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	// Stack is written by the pkgerrors stack marshaler
+	Stack []ZerologFrame `json:"stack,omitempty"`
+}
+
+// ZerologFrame is a frame of the pkgerrors stack marshaler
+type ZerologFrame struct {
+	Func   string `json:"func"`
+	Line   string `json:"line"`
+	Source string `json:"source"`
+}
+
+func (zlm *ZerologLogMessage) toEntry() *Entry {
+	e := &Entry{
+		Level:    ParseLevel(zlm.Level),
+		RawLevel: zlm.Level,
+		Logger:   zlm.Caller,
+		Message:  zlm.Message,
+	}
+
+	e.Time, e.RawTime = parseRawTime(zlm.Time)
+
+	// log message contains an error
+	if zlm.Error != "" || len(zlm.Stack) > 0 {
+		e.Error = &Error{Message: zlm.Error}
+
+		for _, frame := range zlm.Stack {
+			line, _ := strconv.Atoi(frame.Line)
+			e.Error.Frames = append(e.Error.Frames, Frame{Class: frame.Source, Method: frame.Func, Line: line})
+		}
+	}
+
+	return e
+}
+
+// parseRawTime parses a json time value which is either a string or an epoch number
+func parseRawTime(raw json.RawMessage) (time.Time, string) {
+	var value string
+
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		value = strings.TrimSpace(string(raw))
+	}
+
+	return parseTime(value), value
+}
+
+// isZerolog reports whether the keys are written by zerolog, which uses "message"
+// like Quarkus but "time" instead of "timestamp"
+func isZerolog(keys map[string]json.RawMessage) bool {
+	if !hasKeys(keys, "level", "message") {
+		return false
+	}
+
+	return hasKeys(keys, "time") || hasKeys(keys, "caller") || hasKeys(keys, "stack")
+}
@@ -0,0 +1,91 @@
+package humanlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZerologLogMessage_toEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		zlm  ZerologLogMessage
+		want *Entry
+	}{
+		{
+			name: "rfc3339 time",
+			zlm: ZerologLogMessage{
+				Time:    json.RawMessage(`"2021-03-19T13:01:52+01:00"`),
+				Level:   "info",
+				Message: "hello world",
+				Caller:  "/app/main.go:21",
+			},
+			want: &Entry{
+				Time:     time.Date(2021, 3, 19, 13, 1, 52, 0, time.FixedZone("", 60*60)),
+				RawTime:  "2021-03-19T13:01:52+01:00",
+				Level:    LevelInfo,
+				RawLevel: "info",
+				Logger:   "/app/main.go:21",
+				Message:  "hello world",
+			},
+		},
+		{
+			name: "epoch time with stack",
+			zlm: ZerologLogMessage{
+				Time:    json.RawMessage(`1616155312`),
+				Level:   "error",
+				Message: "request failed",
+				Error:   "connection refused",
+				Stack: []ZerologFrame{
+					{Func: "fetch", Line: "42", Source: "client.go"},
+					{Func: "main", Line: "12", Source: "main.go"},
+				},
+			},
+			want: &Entry{
+				Time:     time.Date(2021, 3, 19, 12, 1, 52, 0, time.UTC),
+				RawTime:  "1616155312",
+				Level:    LevelError,
+				RawLevel: "error",
+				Message:  "request failed",
+				Error: &Error{
+					Message: "connection refused",
+					Frames: []Frame{
+						{Class: "client.go", Method: "fetch", Line: 42},
+						{Class: "main.go", Method: "main", Line: 12},
+					},
+				},
+			},
+		},
+		{
+			name: "without time",
+			zlm:  ZerologLogMessage{Level: "debug", Message: "no time"},
+			want: &Entry{Level: LevelDebug, RawLevel: "debug", Message: "no time"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.zlm.toEntry()
+			assert.True(t, tt.want.Time.Equal(got.Time))
+			got.Time = tt.want.Time
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZerolog_Render(t *testing.T) {
+	line := `{"level":"error","stack":[{"func":"fetch","line":"42","source":"client.go"}],"error":"connection refused","time":1616155312,"message":"request failed"}`
+
+	e, err := AutoParser{}.Parse([]byte(line))
+	assert.NoError(t, err)
+	assert.Equal(t, "zerolog", e.Format)
+
+	var w bytes.Buffer
+
+	err = TextRenderer{}.Render(&w, e)
+	assert.NoError(t, err)
+	assert.Equal(t, "ERROR 2021-03-19T12:01:52.000Z\t\trequest failed\nCaused by: connection refused:\n\t at fetch(client.go:42)\n", w.String())
+}